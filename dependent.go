@@ -0,0 +1,38 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package topo
+
+// Dependent represents an element declaring its own dependencies: It is identified by
+// the key returned by ID() and depends upon all elements identified by the keys
+// returned by DependsOn()
+type Dependent[K comparable] interface {
+	ID() K
+	DependsOn() []K
+}
+
+// DependenciesOf returns the dependencies declared by the provided elements (in the
+// order of the elements, followed by the order of the keys they depend upon)
+func DependenciesOf[K comparable, T Dependent[K]](items []T) Dependencies[K] {
+	deps := make(Dependencies[K], 0, len(items))
+	for _, item := range items {
+		for _, parent := range item.DependsOn() {
+			deps = append(deps, Dependency[K]{Child: item.ID(), Parent: parent})
+		}
+	}
+
+	return deps
+}
+
+// SortDependents performs a topological sort on a slice of elements declaring their
+// own dependencies in the same way as SortBy() (sort in place), using the dependencies
+// declared by the elements (cf. DependenciesOf()). Since the key type cannot be
+// inferred, it has to be provided explicitly, e.g. SortDependents[string](items).
+// Errors are returned in the same way as for SortBy()
+func SortDependents[K comparable, T Dependent[K]](items []T, opts ...Option) error {
+	return SortBy(items, T.ID, DependenciesOf[K](items), opts...)
+}
@@ -0,0 +1,62 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package topo
+
+import (
+	"testing"
+
+	"github.com/fako1024/topo/graph"
+	"github.com/stretchr/testify/require"
+)
+
+type plugin struct {
+	name     string
+	requires []string
+	settings map[string]string
+}
+
+func (p plugin) ID() string {
+	return p.name
+}
+
+func (p plugin) DependsOn() []string {
+	return p.requires
+}
+
+func TestSortDependents(t *testing.T) {
+
+	// Based on example_simple_test.go
+	plugins := []plugin{
+		{name: "A", requires: []string{"E"}},
+		{name: "B", requires: []string{"A", "C", "D"}},
+		{name: "C"},
+		{name: "D", requires: []string{"C"}, settings: map[string]string{"key": "value"}},
+		{name: "E"},
+	}
+
+	require.Equal(t, Dependencies[string]{
+		{"A", "E"},
+		{"B", "A"},
+		{"B", "C"},
+		{"B", "D"},
+		{"D", "C"},
+	}, DependenciesOf[string](plugins))
+
+	require.Nil(t, SortDependents[string](plugins))
+	names := make([]string, len(plugins))
+	for i, p := range plugins {
+		names[i] = p.name
+	}
+	require.Equal(t, []string{"E", "A", "C", "D", "B"}, names)
+	require.Equal(t, "value", plugins[3].settings["key"])
+
+	// Dependencies upon absent elements
+	plugins = append(plugins, plugin{name: "F", requires: []string{"X"}})
+	require.ErrorIs(t, SortDependents[string](plugins), graph.ErrVertexNotFound)
+	require.Nil(t, SortDependents[string](plugins, IgnoreMissingElements()))
+}
@@ -0,0 +1,55 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package topo
+
+import "fmt"
+
+// DuplicateElementError is returned if the data to be sorted contains the same element
+// more than once (unless the KeepDuplicates() option is provided)
+type DuplicateElementError[T comparable] struct {
+	Element T
+	First   int
+	Second  int
+}
+
+// Error returns a descriptive string indicating the duplicate element
+func (e *DuplicateElementError[T]) Error() string {
+	return fmt.Sprintf("duplicate element %v at indices %d and %d", e.Element, e.First, e.Second)
+}
+
+// DuplicateDependencyError denotes a dependency that is contained more than once in
+// the list of dependencies
+type DuplicateDependencyError[T comparable] struct {
+	Dependency Dependency[T]
+	First      int
+	Second     int
+}
+
+// Error returns a descriptive string indicating the duplicate dependency
+func (e *DuplicateDependencyError[T]) Error() string {
+	return fmt.Sprintf("duplicate dependency (%s) at indices %d and %d", e.Dependency, e.First, e.Second)
+}
+
+// DependencyError is returned if a specific dependency could not be processed, denoting
+// the dependency along with its index in the list of dependencies. The underlying error
+// (e.g. a *graph.VertexNotFoundError) can be retrieved via errors.Is() / errors.As()
+type DependencyError[T comparable] struct {
+	Index      int
+	Dependency Dependency[T]
+	Err        error
+}
+
+// Error returns a descriptive string indicating the failed dependency
+func (e *DependencyError[T]) Error() string {
+	return fmt.Sprintf("dependency %d (%s): %s", e.Index, e.Dependency, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *DependencyError[T]) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,313 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+// Package exec provides a concurrent executor for directed acyclic graphs, running
+// each node as soon as all nodes it depends upon have been executed successfully
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fako1024/topo"
+	"github.com/fako1024/topo/graph"
+)
+
+// Func denotes the function executed for each node
+type Func[T comparable] func(ctx context.Context, obj T) error
+
+// State denotes the outcome of the execution of a single node
+type State int
+
+const (
+
+	// Succeeded denotes a node that has been executed successfully
+	Succeeded State = iota
+
+	// Failed denotes a node whose execution returned an error
+	Failed
+
+	// Skipped denotes a node that has not been executed because a node it depends
+	// upon (directly or indirectly) has failed
+	Skipped
+
+	// Canceled denotes a node that has not been executed (or whose execution has
+	// been aborted) because the execution was canceled, either by the context or
+	// due to another node failing
+	Canceled
+)
+
+// String returns a human-readable representation of the state
+func (s State) String() string {
+	switch s {
+	case Succeeded:
+		return "succeeded"
+	case Failed:
+		return "failed"
+	case Skipped:
+		return "skipped"
+	case Canceled:
+		return "canceled"
+	}
+
+	return fmt.Sprintf("unknown state (%d)", int(s))
+}
+
+// Result denotes the outcome of the execution of a single node
+type Result[T comparable] struct {
+	Node     T
+	State    State
+	Err      error
+	Duration time.Duration
+}
+
+// Report denotes the outcome of an execution, holding the result of each node (in
+// the order the nodes were added to the graph)
+type Report[T comparable] []Result[T]
+
+// Result returns the result of the execution of a specific node
+func (r Report[T]) Result(node T) (Result[T], bool) {
+	for _, result := range r {
+		if result.Node == node {
+			return result, true
+		}
+	}
+
+	return Result[T]{}, false
+}
+
+// NodeError denotes the failed execution of a single node
+type NodeError[T comparable] struct {
+	Node T
+	Err  error
+}
+
+// Error returns a descriptive string indicating the failed node
+func (e *NodeError[T]) Error() string {
+	return fmt.Sprintf("execution of %v failed: %s", e.Node, e.Err)
+}
+
+// Unwrap returns the error returned by the execution of the node
+func (e *NodeError[T]) Unwrap() error {
+	return e.Err
+}
+
+// Run executes the provided function for all vertices of the graph, each one as soon
+// as all vertices it depends upon have been executed successfully. It returns a report
+// containing the result of each vertex along with an error joining a *NodeError for
+// each failed vertex (and the error of the context, if it was canceled). If the graph
+// contains a cycle, a *graph.CycleError is returned and nothing is executed
+func Run[T comparable](ctx context.Context, gr *graph.Graph[T], fn Func[T], opts ...Option) (Report[T], error) {
+
+	// Make sure the graph can actually be executed before starting anything
+	if _, err := gr.SortTopological(); err != nil {
+		return nil, err
+	}
+
+	return newExecution(gr, fn, newConfig(opts...)).run(ctx)
+}
+
+// RunDependencies executes the provided function for all elements of a slice, each
+// one as soon as all elements it depends upon have been executed successfully. Results
+// and errors are reported in the same way as for Run(), errors constructing the graph
+// are returned in the same way as for topo.Sort() (e.g. a *topo.DuplicateElementError
+// if the slice contains an element more than once)
+func RunDependencies[T comparable](ctx context.Context, data graph.Objects[T], deps topo.Dependencies[T], fn Func[T], opts ...Option) (Report[T], error) {
+
+	gr := graph.NewGraph[T]()
+	for i, obj := range data {
+		if gr.HasVertex(obj) {
+			return nil, &topo.DuplicateElementError[T]{Element: obj, First: slices.Index(data, obj), Second: i}
+		}
+		gr.AddVertex(obj)
+	}
+	for i, dep := range deps {
+		if err := gr.AddArc(dep.Child, dep.Parent); err != nil {
+			return nil, &topo.DependencyError[T]{Index: i, Dependency: dep, Err: err}
+		}
+	}
+
+	return Run(ctx, gr, fn, opts...)
+}
+
+////////////////// Private types / methods /////////////////////////////////////
+
+// outcome denotes the outcome of a single execution of a node
+type outcome[T comparable] struct {
+	node     T
+	err      error
+	duration time.Duration
+}
+
+// execution denotes the state of a single execution of a graph
+type execution[T comparable] struct {
+	fn  Func[T]
+	cfg *config
+
+	nodes     graph.Objects[T]
+	children  map[T]graph.Objects[T]
+	remaining map[T]int
+	results   map[T]*Result[T]
+
+	ready   graph.Objects[T]
+	running int
+	done    chan outcome[T]
+}
+
+// newExecution prepares the execution of a graph
+func newExecution[T comparable](gr *graph.Graph[T], fn Func[T], cfg *config) *execution[T] {
+
+	nodes := gr.Vertices()
+	e := &execution[T]{
+		fn:        fn,
+		cfg:       cfg,
+		nodes:     nodes,
+		children:  make(map[T]graph.Objects[T], len(nodes)),
+		remaining: make(map[T]int, len(nodes)),
+		results:   make(map[T]*Result[T], len(nodes)),
+		done:      make(chan outcome[T]),
+	}
+
+	// Determine the number of dependencies and all dependent nodes of each node,
+	// nodes without any dependencies are ready for execution right away
+	for _, node := range nodes {
+		parents := gr.Parents(node)
+		for _, parent := range parents {
+			e.children[parent] = append(e.children[parent], node)
+		}
+
+		e.remaining[node] = len(parents)
+		if len(parents) == 0 {
+			e.ready = append(e.ready, node)
+		}
+	}
+
+	return e
+}
+
+// run performs the actual execution, launching nodes as soon as they are ready
+// (and permitted by the concurrency limit) until no more nodes are running
+func (e *execution[T]) run(ctx context.Context) (Report[T], error) {
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		errs    []error
+		stopped = ctx.Err() != nil
+		ctxDone = ctx.Done()
+	)
+
+	for {
+
+		// Launch as many ready nodes as permitted
+		for !stopped && len(e.ready) > 0 && (e.cfg.concurrency <= 0 || e.running < e.cfg.concurrency) {
+			node := e.ready[0]
+			e.ready = e.ready[1:]
+			e.launch(runCtx, node)
+		}
+
+		if e.running == 0 {
+			break
+		}
+
+		select {
+		case o := <-e.done:
+			e.running--
+
+			// If the execution was aborted (and the node failed due to it), the node is
+			// considered canceled
+			if o.err != nil && runCtx.Err() != nil && errors.Is(o.err, runCtx.Err()) {
+				e.results[o.node] = &Result[T]{Node: o.node, State: Canceled, Err: o.err, Duration: o.duration}
+				continue
+			}
+
+			if o.err != nil {
+				e.results[o.node] = &Result[T]{Node: o.node, State: Failed, Err: o.err, Duration: o.duration}
+				errs = append(errs, &NodeError[T]{Node: o.node, Err: o.err})
+
+				if !e.cfg.continueOnError {
+					stopped = true
+					cancel()
+					continue
+				}
+
+				e.skipDependents(o.node)
+				continue
+			}
+
+			e.results[o.node] = &Result[T]{Node: o.node, State: Succeeded, Duration: o.duration}
+			e.release(o.node)
+
+		case <-ctxDone:
+			ctxDone, stopped = nil, true
+		}
+	}
+
+	// Include the context error if the execution was canceled from the outside
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return e.report(), errors.Join(errs...)
+}
+
+// launch executes a single node asynchronously
+func (e *execution[T]) launch(ctx context.Context, node T) {
+	e.running++
+	go func() {
+		start := time.Now()
+		err := e.fn(ctx, node)
+		e.done <- outcome[T]{node: node, err: err, duration: time.Since(start)}
+	}()
+}
+
+// release marks a dependency of all children of a node as fulfilled, queueing all
+// children whose dependencies have been fulfilled completely
+func (e *execution[T]) release(node T) {
+	for _, child := range e.children[node] {
+		if e.remaining[child]--; e.remaining[child] == 0 {
+			e.ready = append(e.ready, child)
+		}
+	}
+}
+
+// skipDependents marks all nodes depending upon a node (directly or indirectly) as
+// skipped
+func (e *execution[T]) skipDependents(node T) {
+	queue := graph.Objects[T]{node}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, child := range e.children[current] {
+			if _, exists := e.results[child]; !exists {
+				e.results[child] = &Result[T]{Node: child, State: Skipped}
+				queue = append(queue, child)
+			}
+		}
+	}
+}
+
+// report constructs the final report, considering all nodes that have not been
+// handled as canceled
+func (e *execution[T]) report() Report[T] {
+	report := make(Report[T], len(e.nodes))
+	for i, node := range e.nodes {
+		if result, exists := e.results[node]; exists {
+			report[i] = *result
+			continue
+		}
+
+		report[i] = Result[T]{Node: node, State: Canceled}
+	}
+
+	return report
+}
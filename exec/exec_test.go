@@ -0,0 +1,218 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fako1024/topo"
+	"github.com/fako1024/topo/graph"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+// Based on example_simple_test.go
+var testData = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+
+var testDependencies = []topo.Dependency[string]{
+	{Child: "B", Parent: "A"},
+	{Child: "B", Parent: "C"},
+	{Child: "B", Parent: "D"},
+	{Child: "A", Parent: "E"},
+	{Child: "D", Parent: "C"},
+}
+
+// recorder keeps track of the order in which nodes have been executed
+type recorder struct {
+	sync.Mutex
+	finished []string
+}
+
+func (r *recorder) record(node string) {
+	r.Lock()
+	defer r.Unlock()
+	r.finished = append(r.finished, node)
+}
+
+func (r *recorder) position(node string) int {
+	r.Lock()
+	defer r.Unlock()
+	for i, finished := range r.finished {
+		if finished == node {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestRunOrder(t *testing.T) {
+	for _, concurrency := range []int{0, 1, 2} {
+		rec := &recorder{}
+		report, err := RunDependencies(context.Background(), testData, testDependencies, func(ctx context.Context, node string) error {
+			time.Sleep(time.Millisecond)
+			rec.record(node)
+			return nil
+		}, WithConcurrency(concurrency))
+		require.Nil(t, err)
+		require.Len(t, rec.finished, len(testData))
+
+		// Check if all dependencies are fulfilled
+		for _, dep := range testDependencies {
+			require.Less(t, rec.position(dep.Parent), rec.position(dep.Child))
+		}
+
+		// Check the report (in order of the data)
+		require.Len(t, report, len(testData))
+		for i, result := range report {
+			require.Equal(t, testData[i], result.Node)
+			require.Equal(t, Succeeded, result.State)
+			require.Nil(t, result.Err)
+		}
+	}
+}
+
+func TestRunConcurrency(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	_, err := Run(context.Background(), graph.NewGraph(testData...), func(ctx context.Context, node string) error {
+		n := running.Add(1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	}, WithConcurrency(3))
+	require.Nil(t, err)
+	require.EqualValues(t, 3, maxRunning.Load())
+}
+
+func TestRunFailFast(t *testing.T) {
+	report, err := RunDependencies(context.Background(), testData, testDependencies, func(ctx context.Context, node string) error {
+		if node == "C" {
+			return errTest
+		}
+		return nil
+	}, WithConcurrency(1))
+	require.ErrorIs(t, err, errTest)
+
+	var nodeErr *NodeError[string]
+	require.True(t, errors.As(err, &nodeErr))
+	require.Equal(t, "C", nodeErr.Node)
+	require.EqualError(t, nodeErr, "execution of C failed: test error")
+
+	// C is the first node without dependencies, hence everything else is canceled
+	result, found := report.Result("C")
+	require.True(t, found)
+	require.Equal(t, Failed, result.State)
+	require.ErrorIs(t, result.Err, errTest)
+	for _, node := range []string{"A", "B", "D", "E", "F", "G", "H"} {
+		result, found := report.Result(node)
+		require.True(t, found)
+		require.Equal(t, Canceled, result.State)
+	}
+
+	_, found = report.Result("Z")
+	require.False(t, found)
+}
+
+func TestRunContinueOnError(t *testing.T) {
+	report, err := RunDependencies(context.Background(), testData, testDependencies, func(ctx context.Context, node string) error {
+		if node == "C" {
+			return errTest
+		}
+		return nil
+	}, ContinueOnError())
+	require.ErrorIs(t, err, errTest)
+
+	expectedStates := map[string]State{
+		"A": Succeeded,
+		"B": Skipped,
+		"C": Failed,
+		"D": Skipped,
+		"E": Succeeded,
+		"F": Succeeded,
+		"G": Succeeded,
+		"H": Succeeded,
+	}
+	for _, result := range report {
+		require.Equal(t, expectedStates[result.Node], result.State, result.Node)
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	report, err := RunDependencies(ctx, testData, testDependencies, func(ctx context.Context, node string) error {
+		if node == "E" {
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}, WithConcurrency(1))
+	require.ErrorIs(t, err, context.Canceled)
+
+	var nodeErr *NodeError[string]
+	require.False(t, errors.As(err, &nodeErr))
+
+	// A depends upon E and hence must not have been executed
+	for _, node := range []string{"A", "B", "E"} {
+		result, found := report.Result(node)
+		require.True(t, found)
+		require.Equal(t, Canceled, result.State)
+	}
+
+	// Nothing is executed if the context has been canceled beforehand
+	var executed atomic.Int32
+	report, err = RunDependencies(ctx, testData, testDependencies, func(ctx context.Context, node string) error {
+		executed.Add(1)
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Zero(t, executed.Load())
+	for _, result := range report {
+		require.Equal(t, Canceled, result.State)
+	}
+}
+
+func TestRunInvalid(t *testing.T) {
+	fn := func(ctx context.Context, node string) error {
+		t.Fatal("unexpected execution")
+		return nil
+	}
+
+	_, err := RunDependencies(context.Background(), testData, append(testDependencies, topo.Dependency[string]{Child: "C", Parent: "B"}), fn)
+	var cycleErr *graph.CycleError[string]
+	require.True(t, errors.As(err, &cycleErr))
+
+	_, err = RunDependencies(context.Background(), testData, []topo.Dependency[string]{{Child: "Z", Parent: "A"}}, fn)
+	require.ErrorContains(t, err, "source vertex Z not found in graph")
+
+	// Duplicate elements are rejected in the same way as for topo.Sort()
+	var duplicateErr *topo.DuplicateElementError[string]
+	report, err := RunDependencies(context.Background(), []string{"A", "B", "A"}, nil, fn)
+	require.True(t, errors.As(err, &duplicateErr))
+	require.EqualError(t, err, "duplicate element A at indices 0 and 2")
+	require.Empty(t, report)
+}
+
+func TestStateString(t *testing.T) {
+	require.Equal(t, "succeeded", Succeeded.String())
+	require.Equal(t, "failed", Failed.String())
+	require.Equal(t, "skipped", Skipped.String())
+	require.Equal(t, "canceled", Canceled.String())
+	require.Equal(t, "unknown state (42)", State(42).String())
+}
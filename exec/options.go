@@ -0,0 +1,45 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package exec
+
+// Option represents a functional option to configure the behavior of an execution
+type Option func(*config)
+
+// config denotes the configuration of an execution (as defined by the provided options)
+type config struct {
+	concurrency     int
+	continueOnError bool
+}
+
+// newConfig returns a new configuration with all provided options applied
+func newConfig(opts ...Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithConcurrency limits the number of nodes being executed concurrently (by default
+// or if n <= 0, all nodes whose dependencies have been fulfilled are executed at once)
+func WithConcurrency(n int) Option {
+	return func(cfg *config) {
+		cfg.concurrency = n
+	}
+}
+
+// ContinueOnError causes the execution to proceed with all nodes that do not depend
+// upon a failed node (directly or indirectly) in case a node fails. By default, the
+// execution stops as soon as the first node fails (canceling the context of all nodes
+// that are still running)
+func ContinueOnError() Option {
+	return func(cfg *config) {
+		cfg.continueOnError = true
+	}
+}
@@ -0,0 +1,22 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+import "fmt"
+
+// Arc represents a line / arc of a graph, denoting that the source vertex depends
+// upon the destination vertex
+type Arc[T comparable] struct {
+	From T
+	To   T
+}
+
+// String returns a generic string denoting the connection between both vertices
+func (a Arc[T]) String() string {
+	return fmt.Sprintf("%v -> %v", a.From, a.To)
+}
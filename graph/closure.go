@@ -0,0 +1,119 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+// Ancestors returns all vertices the provided vertex depends upon, directly or
+// indirectly (in topological order, excluding the vertex itself). If the vertex does
+// not exist, a *VertexNotFoundError is returned, if the vertex or any of its ancestors
+// are part of a cycle, a *CycleError is returned
+func (g *Graph[T]) Ancestors(obj T) (Objects[T], error) {
+	return g.AncestorsUpTo(obj, 0)
+}
+
+// AncestorsUpTo returns all vertices the provided vertex depends upon, directly or
+// indirectly up to the provided depth (where a depth of one only yields the vertices
+// it directly depends upon and a depth <= 0 is unlimited). Results and errors are
+// returned in the same way as for Ancestors()
+func (g *Graph[T]) AncestorsUpTo(obj T, depth int) (Objects[T], error) {
+	return g.closure(obj, depth, vertex[T].arcs)
+}
+
+// Descendants returns all vertices depending upon the provided vertex, directly or
+// indirectly (in topological order, excluding the vertex itself). If the vertex does
+// not exist, a *VertexNotFoundError is returned, if the vertex or any of its
+// descendants are part of a cycle, a *CycleError is returned
+func (g *Graph[T]) Descendants(obj T) (Objects[T], error) {
+	return g.DescendantsUpTo(obj, 0)
+}
+
+// DescendantsUpTo returns all vertices depending upon the provided vertex, directly
+// or indirectly up to the provided depth (where a depth of one only yields the vertices
+// directly depending upon it and a depth <= 0 is unlimited). Results and errors are
+// returned in the same way as for Descendants()
+func (g *Graph[T]) DescendantsUpTo(obj T, depth int) (Objects[T], error) {
+	return g.closure(obj, depth, vertex[T].dependents)
+}
+
+// SubgraphFor returns the provided target vertices along with all vertices they
+// depend upon, directly or indirectly (in topological order). All other vertices are
+// disregarded, hence cycles not involving any of these vertices do not cause an
+// error. If any of the targets does not exist, a *VertexNotFoundError is returned,
+// if any of the returned vertices is part of a cycle, a *CycleError is returned
+func (g *Graph[T]) SubgraphFor(targets ...T) (Objects[T], error) {
+
+	members := newList[T]()
+	for _, target := range targets {
+		if _, found := g.find(target); !found {
+			return nil, &VertexNotFoundError[T]{Vertex: target}
+		}
+
+		// Skip targets that have already been reached via another target
+		if isNew := members.add(target); !isNew {
+			continue
+		}
+		for _, obj := range g.reachable(target, 0, vertex[T].arcs).elements {
+			members.add(obj)
+		}
+	}
+
+	return g.sortTopological(members)
+}
+
+////////////////// Private methods /////////////////////////////////////////////
+
+// closure determines all vertices reachable from the provided vertex (following the
+// connections provided by the next function up to the provided depth) and returns them
+// in topological order (excluding the vertex itself)
+func (g *Graph[T]) closure(obj T, depth int, next func(vertex[T]) Objects[T]) (Objects[T], error) {
+
+	if _, found := g.find(obj); !found {
+		return nil, &VertexNotFoundError[T]{Vertex: obj}
+	}
+
+	// Sort all reachable vertices (including the vertex itself to detect potential
+	// cycles running through it)
+	members := g.reachable(obj, depth, next)
+	members.add(obj)
+	sorted, err := g.sortTopological(members)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the vertex itself from the result
+	result := make(Objects[T], 0, len(sorted)-1)
+	for _, member := range sorted {
+		if member != obj {
+			result = append(result, member)
+		}
+	}
+
+	return result, nil
+}
+
+// reachable determines all vertices reachable from the provided vertex (following the
+// connections provided by the next function up to the provided depth, where a depth
+// <= 0 is unlimited) using a breadth-first search. The vertices are listed in the
+// order they were reached
+func (g *Graph[T]) reachable(obj T, depth int, next func(vertex[T]) Objects[T]) *list[T] {
+
+	reached := newList[T]()
+	queue := Objects[T]{obj}
+	for level := 1; len(queue) > 0 && (depth <= 0 || level <= depth); level++ {
+		var nextQueue Objects[T]
+		for _, current := range queue {
+			for _, connected := range next(g.vertices[current]) {
+				if isNew := reached.add(connected); isNew {
+					nextQueue = append(nextQueue, connected)
+				}
+			}
+		}
+		queue = nextQueue
+	}
+
+	return reached
+}
@@ -0,0 +1,212 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+import "slices"
+
+// FindCycles returns one representative (shortest) cycle for each strongly connected
+// component of the graph that contains a cycle. Each cycle is denoted in the same way
+// as in CycleError, starting at the earliest added vertex of its component. The cycles
+// are ordered by the position of said vertex. If the graph is acyclic, an empty list
+// is returned
+func (g *Graph[T]) FindCycles() []Objects[T] {
+
+	cycles := make([]Objects[T], 0)
+	for _, component := range g.StronglyConnectedComponents() {
+
+		// Determine the members of the component to restrict the search to
+		members := make(map[T]struct{}, len(component))
+		for _, obj := range component {
+			members[obj] = struct{}{}
+		}
+
+		// Components without cycle (single vertices) are skipped
+		if cycle := g.shortestCycle(component[0], members); cycle != nil {
+			cycles = append(cycles, cycle)
+		}
+	}
+
+	return cycles
+}
+
+// StronglyConnectedComponents determines all strongly connected components of the
+// graph, i.e. all maximal sets of vertices that can all reach each other (which is
+// the case for all vertices that are part of a common cycle). Vertices that are not
+// part of any cycle form a component of their own. The vertices of each component
+// are ordered by the order they were added, the components are ordered by the position
+// of their first vertex
+func (g *Graph[T]) StronglyConnectedComponents() []Objects[T] {
+
+	positions := g.positions()
+	components := g.components()
+	slices.SortFunc(components, func(a, b Objects[T]) int {
+		return positions[a[0]] - positions[b[0]]
+	})
+
+	return components
+}
+
+// Condensation returns the condensation of the graph, i.e. an (always acyclic) graph
+// whose vertices represent the strongly connected components of the graph: Vertex i
+// denotes the i-th component returned alongside it (in the same order as returned by
+// StronglyConnectedComponents()), an arc between two components exists if any arc
+// between their respective vertices exists
+func (g *Graph[T]) Condensation() (*Graph[int], []Objects[T]) {
+
+	components := g.StronglyConnectedComponents()
+	membership := make(map[T]int, len(g.order))
+	condensation := NewGraph[int]()
+	for i, component := range components {
+		condensation.AddVertex(i)
+		for _, obj := range component {
+			membership[obj] = i
+		}
+	}
+
+	// Add all arcs between different components (in the order of their vertices)
+	for _, obj := range g.order {
+		for _, arc := range g.vertices[obj].arcs() {
+			if membership[obj] != membership[arc] {
+				condensation.addArc(membership[obj], membership[arc])
+			}
+		}
+	}
+
+	return condensation, components
+}
+
+////////////////// Private methods /////////////////////////////////////////////
+
+// positions returns the index of each vertex in the order of addition
+func (g *Graph[T]) positions() map[T]int {
+	positions := make(map[T]int, len(g.order))
+	for i, obj := range g.order {
+		positions[obj] = i
+	}
+
+	return positions
+}
+
+// components determines all strongly connected components of the graph (using an
+// iterative variant of Tarjan's algorithm). The vertices of each component are
+// ordered by their position, the components themselves are ordered such that each
+// component only depends upon components preceding it
+func (g *Graph[T]) components() []Objects[T] {
+
+	var (
+		positions = g.positions()
+		index     = make(map[T]int, len(g.order))
+		lowLink   = make(map[T]int, len(g.order))
+		onStack   = make(map[T]bool, len(g.order))
+		stack     = make([]T, 0)
+		callStack = make([]frame[T], 0)
+		result    = make([]Objects[T], 0)
+	)
+
+	// visit assigns the next index to a vertex and puts it on both stacks
+	visit := func(obj T) {
+		index[obj], lowLink[obj] = len(index), len(index)
+		stack = append(stack, obj)
+		onStack[obj] = true
+		callStack = append(callStack, frame[T]{obj: obj, arcs: g.vertices[obj].arcs()})
+	}
+
+	for _, root := range g.order {
+		if _, visited := index[root]; visited {
+			continue
+		}
+
+		visit(root)
+		for len(callStack) > 0 {
+			current := &callStack[len(callStack)-1]
+
+			// Descend into the next connected vertex (if any)
+			if current.pos < len(current.arcs) {
+				next := current.arcs[current.pos]
+				current.pos++
+
+				if _, visited := index[next]; !visited {
+					visit(next)
+				} else if onStack[next] {
+					lowLink[current.obj] = min(lowLink[current.obj], index[next])
+				}
+				continue
+			}
+
+			// All connected vertices have been visited, propagate the low link to
+			// the calling vertex
+			obj := current.obj
+			callStack = callStack[:len(callStack)-1]
+			if len(callStack) > 0 {
+				caller := callStack[len(callStack)-1].obj
+				lowLink[caller] = min(lowLink[caller], lowLink[obj])
+			}
+
+			// If the vertex is the root of a component, pop the component from the stack
+			if lowLink[obj] == index[obj] {
+				var component Objects[T]
+				for {
+					member := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[member] = false
+					component = append(component, member)
+					if member == obj {
+						break
+					}
+				}
+
+				slices.SortFunc(component, func(a, b T) int {
+					return positions[a] - positions[b]
+				})
+				result = append(result, component)
+			}
+		}
+	}
+
+	return result
+}
+
+// shortestCycle determines the shortest cycle starting and ending at the provided
+// vertex (using a breadth-first search restricted to the provided members). If no
+// such cycle exists, nil is returned
+func (g *Graph[T]) shortestCycle(start T, members map[T]struct{}) Objects[T] {
+
+	predecessors := make(map[T]T)
+	queue := Objects[T]{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, arc := range g.vertices[current].arcs() {
+
+			// If the start vertex has been reached, reconstruct the path backwards
+			if arc == start {
+				cycle := Objects[T]{current}
+				for obj := current; obj != start; {
+					obj = predecessors[obj]
+					cycle = append(cycle, obj)
+				}
+				slices.Reverse(cycle)
+
+				return append(cycle, start)
+			}
+
+			if _, isMember := members[arc]; !isMember {
+				continue
+			}
+			if _, seen := predecessors[arc]; seen {
+				continue
+			}
+
+			predecessors[arc] = current
+			queue = append(queue, arc)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrVertexNotFound denotes that a vertex does not exist in the graph (can be
+	// checked for via errors.Is() on any *VertexNotFoundError)
+	ErrVertexNotFound = errors.New("vertex not found in graph")
+
+	// ErrArcNotFound denotes that a line / arc does not exist in the graph (can be
+	// checked for via errors.Is() on any *ArcNotFoundError)
+	ErrArcNotFound = errors.New("arc not found in graph")
+
+	// ErrSelfLoop denotes that a vertex depends upon itself (can be checked for via
+	// errors.Is() on any *SelfLoopError)
+	ErrSelfLoop = errors.New("vertex depends upon itself")
+
+	// ErrGraphTooLarge denotes that a graph is too large for an exact computation
+	ErrGraphTooLarge = errors.New("graph too large for exact computation")
+)
+
+// Endpoint denotes the role of a vertex with respect to a line / arc
+type Endpoint int
+
+const (
+
+	// NoEndpoint denotes a vertex that is not referred to as part of an arc
+	NoEndpoint Endpoint = iota
+
+	// Source denotes the source vertex of an arc (i.e. the one depending upon the other)
+	Source
+
+	// Destination denotes the destination vertex of an arc (i.e. the one being depended
+	// upon)
+	Destination
+)
+
+// CycleError is returned if a graph contains a cycle and hence cannot be sorted
+// topologically. It can be retrieved from any returned error via errors.As()
+type CycleError[T comparable] struct {
+
+	// Cycle denotes the vertices forming the cycle, starting and ending with the
+	// same vertex. Each consecutive pair of vertices corresponds to an arc, i.e.
+	// the first vertex depends upon the second one
+	Cycle Objects[T]
+}
+
+// Error returns a descriptive string indicating the cycle
+func (e *CycleError[T]) Error() string {
+	return fmt.Sprintf("cycle error: %s", e.Cycle.String())
+}
+
+// VertexNotFoundError is returned if an operation refers to a vertex that does not
+// exist in the graph. If the vertex was referred to as part of an arc, its Endpoint
+// denotes if it is the source or destination vertex of the arc
+type VertexNotFoundError[T comparable] struct {
+	Vertex   T
+	Endpoint Endpoint
+}
+
+// Error returns a descriptive string indicating the missing vertex
+func (e *VertexNotFoundError[T]) Error() string {
+	switch e.Endpoint {
+	case Source:
+		return fmt.Sprintf("source vertex %v not found in graph", e.Vertex)
+	case Destination:
+		return fmt.Sprintf("destination vertex %v not found in graph", e.Vertex)
+	}
+
+	return fmt.Sprintf("vertex %v not found in graph", e.Vertex)
+}
+
+// Is allows to match the error against ErrVertexNotFound via errors.Is()
+func (e *VertexNotFoundError[T]) Is(target error) bool {
+	return target == ErrVertexNotFound
+}
+
+// ArcNotFoundError is returned if an operation refers to a line / arc that does not
+// exist in the graph
+type ArcNotFoundError[T comparable] struct {
+	From T
+	To   T
+}
+
+// Error returns a descriptive string indicating the missing arc
+func (e *ArcNotFoundError[T]) Error() string {
+	return fmt.Sprintf("arc %v -> %v not found in graph", e.From, e.To)
+}
+
+// Is allows to match the error against ErrArcNotFound via errors.Is()
+func (e *ArcNotFoundError[T]) Is(target error) bool {
+	return target == ErrArcNotFound
+}
+
+// SelfLoopError is returned if a line / arc from a vertex to itself (i.e. a vertex
+// depending upon itself) is added to the graph
+type SelfLoopError[T comparable] struct {
+	Vertex T
+}
+
+// Error returns a descriptive string indicating the vertex depending upon itself
+func (e *SelfLoopError[T]) Error() string {
+	return fmt.Sprintf("self-loop error: vertex %v depends upon itself", e.Vertex)
+}
+
+// Is allows to match the error against ErrSelfLoop via errors.Is()
+func (e *SelfLoopError[T]) Is(target error) bool {
+	return target == ErrSelfLoop
+}
@@ -0,0 +1,257 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+import (
+	"container/heap"
+	"math/bits"
+)
+
+// maxExactComponentSize denotes the maximum number of vertices of a strongly connected
+// component for which FeedbackArcSetExact() computes a minimum feedback arc set
+const maxExactComponentSize = 20
+
+// FeedbackArcSet determines a small set of lines / arcs whose removal makes the graph
+// acyclic (using the heuristic by Eades, Lin and Smyth on each strongly connected
+// component). The set is not guaranteed to be minimal, but only contains arcs that are
+// part of a cycle, and it is deterministic with respect to the order of addition. The
+// arcs are ordered in the same way as for Arcs(). If the graph is acyclic, an empty
+// list is returned
+func (g *Graph[T]) FeedbackArcSet() []Arc[T] {
+	arcs, _ := g.feedbackArcSet(g.eadesLinSmyth)
+	return arcs
+}
+
+// FeedbackArcSetExact determines a minimum set of lines / arcs whose removal makes the
+// graph acyclic (using dynamic programming over all subsets of each strongly connected
+// component). Since the effort grows exponentially, ErrGraphTooLarge is returned if any
+// component exceeds 20 vertices. The arcs are ordered in the same way as for Arcs(). If
+// the graph is acyclic, an empty list is returned
+func (g *Graph[T]) FeedbackArcSetExact() ([]Arc[T], error) {
+	return g.feedbackArcSet(g.minimumOrder)
+}
+
+////////////////// Private methods /////////////////////////////////////////////
+
+// feedbackArcSet determines a feedback arc set by arranging the vertices of each
+// strongly connected component containing a cycle in a linear order (using the
+// provided function) and collecting all arcs pointing backwards in said order
+func (g *Graph[T]) feedbackArcSet(arrange func(component Objects[T]) (Objects[T], error)) ([]Arc[T], error) {
+
+	// Determine the position of each vertex within the linear order of its component
+	// (vertices that are not part of a cycle are disregarded)
+	var (
+		component = make(map[T]int, len(g.order))
+		positions = make(map[T]int, len(g.order))
+	)
+	for i, members := range g.StronglyConnectedComponents() {
+		if len(members) < 2 {
+			continue
+		}
+
+		order, err := arrange(members)
+		if err != nil {
+			return nil, err
+		}
+		for pos, obj := range order {
+			component[obj], positions[obj] = i, pos
+		}
+	}
+
+	// Collect all arcs within a component pointing backwards
+	arcs := make([]Arc[T], 0)
+	for _, arc := range g.Arcs() {
+		from, isMember := component[arc.From]
+		if to, found := component[arc.To]; !isMember || !found || from != to {
+			continue
+		}
+		if positions[arc.From] > positions[arc.To] {
+			arcs = append(arcs, arc)
+		}
+	}
+
+	return arcs, nil
+}
+
+// eadesLinSmyth arranges the vertices of a strongly connected component in a linear
+// order with few arcs pointing backwards: Vertices without (remaining) arcs are
+// successively placed at the end and vertices without (remaining) dependents at the
+// beginning. If neither exist, the vertex maximizing the difference between the number
+// of arcs and dependents is placed at the beginning (ties being broken by position).
+// Such vertices are tracked in queues and a priority queue (updated lazily whenever a
+// vertex is removed), hence the effort is O((V + E) * log(V))
+func (g *Graph[T]) eadesLinSmyth(component Objects[T]) (Objects[T], error) {
+
+	var (
+		positions = make(map[T]int, len(component))
+		outDeg    = make(map[T]int, len(component))
+		inDeg     = make(map[T]int, len(component))
+		head      = make(Objects[T], 0, len(component))
+		tail      = make(Objects[T], 0, len(component))
+		sinks     = make(Objects[T], 0)
+		sources   = make(Objects[T], 0)
+	)
+	for i, obj := range component {
+		positions[obj] = i
+	}
+	for _, obj := range component {
+		for _, arc := range g.vertices[obj].arcs() {
+			if _, isMember := positions[arc]; isMember {
+				outDeg[obj]++
+				inDeg[arc]++
+			}
+		}
+	}
+
+	// Vertices are prioritized by the difference between their number of arcs and
+	// dependents at the time they were pushed (entries that have become outdated
+	// are skipped)
+	candidates := &priorityQueue[delta[T]]{less: func(a, b delta[T]) bool {
+		if a.delta != b.delta {
+			return a.delta > b.delta
+		}
+		return positions[a.obj] < positions[b.obj]
+	}}
+	for _, obj := range component {
+		heap.Push(candidates, delta[T]{obj: obj, delta: outDeg[obj] - inDeg[obj]})
+		if outDeg[obj] == 0 {
+			sinks = append(sinks, obj)
+		} else if inDeg[obj] == 0 {
+			sources = append(sources, obj)
+		}
+	}
+
+	// remove removes a vertex from the remaining ones, updating all connected vertices
+	// (and registering them as sink / source or with their new priority)
+	remove := func(obj T) {
+		delete(positions, obj)
+		for _, arc := range g.vertices[obj].arcs() {
+			if _, isMember := positions[arc]; isMember {
+				if inDeg[arc]--; inDeg[arc] == 0 {
+					sources = append(sources, arc)
+				}
+				heap.Push(candidates, delta[T]{obj: arc, delta: outDeg[arc] - inDeg[arc]})
+			}
+		}
+		for _, dependent := range g.vertices[obj].dependents() {
+			if _, isMember := positions[dependent]; isMember {
+				if outDeg[dependent]--; outDeg[dependent] == 0 {
+					sinks = append(sinks, dependent)
+				}
+				heap.Push(candidates, delta[T]{obj: dependent, delta: outDeg[dependent] - inDeg[dependent]})
+			}
+		}
+	}
+
+	// isRemaining determines if a vertex has not been placed yet
+	isRemaining := func(obj T) bool {
+		_, isMember := positions[obj]
+		return isMember
+	}
+
+	for len(positions) > 0 {
+
+		// Place all vertices without arcs at the end and all vertices without dependents
+		// at the beginning (until there are none left)
+		for len(sinks) > 0 || len(sources) > 0 {
+			for len(sinks) > 0 {
+				obj := sinks[0]
+				sinks = sinks[1:]
+				if isRemaining(obj) {
+					tail = append(tail, obj)
+					remove(obj)
+				}
+			}
+			if len(sources) > 0 {
+				obj := sources[0]
+				sources = sources[1:]
+				if isRemaining(obj) {
+					head = append(head, obj)
+					remove(obj)
+				}
+			}
+		}
+
+		// Place the vertex with the maximum difference between arcs and dependents at
+		// the beginning
+		for candidates.Len() > 0 {
+			candidate := heap.Pop(candidates).(delta[T])
+			if isRemaining(candidate.obj) && candidate.delta == outDeg[candidate.obj]-inDeg[candidate.obj] {
+				head = append(head, candidate.obj)
+				remove(candidate.obj)
+				break
+			}
+		}
+	}
+
+	// The vertices placed at the end were collected in reverse order
+	for i := len(tail) - 1; i >= 0; i-- {
+		head = append(head, tail[i])
+	}
+
+	return head, nil
+}
+
+// delta denotes a vertex along with the difference between its number of arcs and
+// dependents at a certain point in time
+type delta[T comparable] struct {
+	obj   T
+	delta int
+}
+
+// minimumOrder arranges the vertices of a strongly connected component in a linear
+// order with the minimum number of arcs pointing backwards: For each subset of vertices
+// (represented as bit mask), the minimum number of backward arcs among all orders
+// starting with said subset is determined from all smaller subsets
+func (g *Graph[T]) minimumOrder(component Objects[T]) (Objects[T], error) {
+
+	n := len(component)
+	if n > maxExactComponentSize {
+		return nil, ErrGraphTooLarge
+	}
+
+	// Determine the arcs of each vertex within the component (as bit mask)
+	indices := make(map[T]int, n)
+	for i, obj := range component {
+		indices[obj] = i
+	}
+	arcs := make([]uint32, n)
+	for i, obj := range component {
+		for _, arc := range g.vertices[obj].arcs() {
+			if j, isMember := indices[arc]; isMember {
+				arcs[i] |= 1 << j
+			}
+		}
+	}
+
+	// Appending a vertex to a subset causes all of its arcs pointing into the subset
+	// to point backwards
+	costs := make([]int, 1<<n)
+	choices := make([]int8, 1<<n)
+	for set := 1; set < 1<<n; set++ {
+		costs[set] = -1
+		for i := 0; i < n; i++ {
+			if set&(1<<i) == 0 {
+				continue
+			}
+			prev := set &^ (1 << i)
+			if cost := costs[prev] + bits.OnesCount32(arcs[i]&uint32(prev)); costs[set] < 0 || cost < costs[set] {
+				costs[set], choices[set] = cost, int8(i)
+			}
+		}
+	}
+
+	// Reconstruct the order backwards from the full set
+	order := make(Objects[T], n)
+	for set, pos := 1<<n-1, n-1; set > 0; pos-- {
+		order[pos] = component[choices[set]]
+		set &^= 1 << choices[set]
+	}
+
+	return order, nil
+}
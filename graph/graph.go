@@ -7,7 +7,7 @@
 
 package graph
 
-import "fmt"
+import "slices"
 
 // Indicates the non-existence in find() methods
 const indexNoExist = -1
@@ -16,19 +16,26 @@ const indexNoExist = -1
 // arcs in accordance with a discrete graph description
 type Graph[T comparable] struct {
 	vertices map[T]vertex[T]
-	order    []T
+	order    Objects[T]
+	cfg      config
 }
 
 // NewGraph returns a new graph representation (constructor)
 func NewGraph[T comparable](objects ...T) *Graph[T] {
-	gr := Graph[T]{make(map[T]vertex[T]), make([]T, 0)}
+	gr := New[T]()
 
 	// Optionally add all vertices already provided variadically
 	for _, obj := range objects {
 		gr.AddVertex(obj)
 	}
 
-	return &gr
+	return gr
+}
+
+// New returns a new, empty graph representation, configured by the provided options
+// (constructor)
+func New[T comparable](opts ...Option) *Graph[T] {
+	return &Graph[T]{make(map[T]vertex[T]), make(Objects[T], 0), newConfig(opts...)}
 }
 
 // AddVertex adds a node / vertex to the graph
@@ -39,44 +46,220 @@ func (g *Graph[T]) AddVertex(obj T) {
 	}
 }
 
-// AddArc adds a line / arc to the graph
+// AddArc adds a line / arc to the graph. By default, both vertices must exist
+// (otherwise a *VertexNotFoundError is returned), which can be relaxed via the
+// AddMissingVertices() and IgnoreMissingVertices() options. An arc from a vertex to
+// itself is rejected with a *SelfLoopError (unless the IgnoreSelfLoops() option is set)
 func (g *Graph[T]) AddArc(arcFrom, arcTo T) error {
 
+	// Handle arcs from a vertex to itself according to the configuration
+	if arcFrom == arcTo {
+		if g.cfg.ignoreSelfLoops {
+			return nil
+		}
+		return &SelfLoopError[T]{Vertex: arcFrom}
+	}
+
+	// Handle missing vertices according to the configuration
+	if !g.HasVertex(arcFrom) || !g.HasVertex(arcTo) {
+		switch g.cfg.missingVertices {
+		case missingVerticesIgnore:
+			return nil
+		case missingVerticesAdd:
+			g.AddVertex(arcFrom)
+			g.AddVertex(arcTo)
+		}
+	}
+
 	// Check if the "source" vertex exists
 	sourceVertex, ok := g.vertices[arcFrom]
 	if !ok {
-		return fmt.Errorf("source vertex %v not found in graph", arcFrom)
+		return &VertexNotFoundError[T]{Vertex: arcFrom, Endpoint: Source}
 	}
 
 	// Check if the "destination" vertex exists
-	if _, ok := g.vertices[arcTo]; !ok {
-		return fmt.Errorf("destination vertex %v not found in graph", arcTo)
+	destinationVertex, ok := g.vertices[arcTo]
+	if !ok {
+		return &VertexNotFoundError[T]{Vertex: arcTo, Endpoint: Destination}
 	}
 
 	// Add the arc from "source" to "destination" vertex
 	sourceVertex.addArc(arcTo)
+	destinationVertex.addDependent(arcFrom)
 
 	return nil
 }
 
-// SortTopological performs a topological sort and returns the sorted list of
-// arbitrary input types
-func (g *Graph[T]) SortTopological() (Objects[T], error) {
-	var (
-		results = newList[T]()
-		err     error
-	)
+// AddSoftArc adds an optional line / arc to the graph, which is only added if possible:
+// If either vertex does not exist or if the arc would close a cycle (including an arc
+// from a vertex to itself), it is silently dropped. Hence, soft arcs never cause a sort
+// to fail, but it depends on the order of addition which of several conflicting soft
+// arcs are dropped. Returns true if the arc was added (or already existed). Note that
+// detecting a cycle requires a search of all vertices reachable from the destination
+// vertex, hence adding S soft arcs takes O(S * (V + E)) time in the worst case
+func (g *Graph[T]) AddSoftArc(arcFrom, arcTo T) bool {
 
-	// Recursively check each vertex for connected vertices and construct the
-	// sorted list
+	if !g.HasVertex(arcFrom) || !g.HasVertex(arcTo) || arcFrom == arcTo {
+		return false
+	}
+
+	// Drop the arc if the source vertex is reachable from the destination vertex
+	reached := g.reachable(arcTo, 0, vertex[T].arcs)
+	if _, found := reached.findIndex(arcFrom); found {
+		return false
+	}
+
+	g.addArc(arcFrom, arcTo)
+
+	return true
+}
+
+// RemoveVertex removes a node / vertex from the graph, along with all lines / arcs
+// from and to it. If the vertex does not exist, a *VertexNotFoundError is returned
+func (g *Graph[T]) RemoveVertex(obj T) error {
+
+	vertex, found := g.find(obj)
+	if !found {
+		return &VertexNotFoundError[T]{Vertex: obj}
+	}
+
+	// Remove all arcs from and to the vertex from the respective connected vertices
+	for _, arc := range vertex.arcs() {
+		g.vertices[arc].removeDependent(obj)
+	}
+	for _, dependent := range vertex.dependents() {
+		g.vertices[dependent].removeArc(obj)
+	}
+
+	// Remove the vertex itself (retaining the order of all other vertices)
+	delete(g.vertices, obj)
+	g.order = slices.DeleteFunc(g.order, func(elem T) bool {
+		return elem == obj
+	})
+
+	return nil
+}
+
+// RemoveArc removes a line / arc from the graph. If the arc does not exist, an
+// *ArcNotFoundError is returned
+func (g *Graph[T]) RemoveArc(arcFrom, arcTo T) error {
+
+	sourceVertex, found := g.find(arcFrom)
+	if !found || !sourceVertex.removeArc(arcTo) {
+		return &ArcNotFoundError[T]{From: arcFrom, To: arcTo}
+	}
+	g.vertices[arcTo].removeDependent(arcFrom)
+
+	return nil
+}
+
+// Vertices returns all vertices of the graph (in the order they were added)
+func (g *Graph[T]) Vertices() Objects[T] {
+	return g.order.clone()
+}
+
+// Arcs returns all lines / arcs of the graph (ordered by the order their source
+// vertices were added, followed by the order the arcs were added)
+func (g *Graph[T]) Arcs() []Arc[T] {
+	arcs := make([]Arc[T], 0, g.ArcCount())
 	for _, obj := range g.order {
-		var seen = newList[T]()
-		if err = g.analyze(obj, results, seen); err != nil {
-			return nil, err
+		for _, arc := range g.vertices[obj].arcs() {
+			arcs = append(arcs, Arc[T]{From: obj, To: arc})
 		}
 	}
 
-	return results.elements, nil
+	return arcs
+}
+
+// Parents returns all vertices the provided vertex depends upon, i.e. all vertices
+// its arcs point to (in the order the arcs were added). If the vertex does not
+// exist, nil is returned
+func (g *Graph[T]) Parents(obj T) Objects[T] {
+	vertex, found := g.find(obj)
+	if !found {
+		return nil
+	}
+
+	return vertex.arcs().clone()
+}
+
+// Children returns all vertices depending upon the provided vertex, i.e. all vertices
+// with arcs pointing to it (in the order the arcs were added). If the vertex does not
+// exist, nil is returned
+func (g *Graph[T]) Children(obj T) Objects[T] {
+	vertex, found := g.find(obj)
+	if !found {
+		return nil
+	}
+
+	return vertex.dependents().clone()
+}
+
+// InDegree returns the number of arcs pointing to the provided vertex, i.e. the
+// number of vertices depending upon it (or zero if the vertex does not exist)
+func (g *Graph[T]) InDegree(obj T) int {
+	vertex, found := g.find(obj)
+	if !found {
+		return 0
+	}
+
+	return len(vertex.dependents())
+}
+
+// OutDegree returns the number of arcs originating from the provided vertex, i.e. the
+// number of vertices it depends upon (or zero if the vertex does not exist)
+func (g *Graph[T]) OutDegree(obj T) int {
+	vertex, found := g.find(obj)
+	if !found {
+		return 0
+	}
+
+	return len(vertex.arcs())
+}
+
+// HasVertex determines if the graph contains a specific vertex
+func (g *Graph[T]) HasVertex(obj T) bool {
+	_, found := g.find(obj)
+	return found
+}
+
+// HasArc determines if the graph contains a specific line / arc
+func (g *Graph[T]) HasArc(arcFrom, arcTo T) bool {
+	vertex, found := g.find(arcFrom)
+	if !found {
+		return false
+	}
+
+	_, found = vertex.arcList.findIndex(arcTo)
+	return found
+}
+
+// Len returns the number of vertices of the graph
+func (g *Graph[T]) Len() int {
+	return len(g.order)
+}
+
+// ArcCount returns the number of lines / arcs of the graph
+func (g *Graph[T]) ArcCount() int {
+	count := 0
+	for _, vertex := range g.vertices {
+		count += len(vertex.arcs())
+	}
+
+	return count
+}
+
+// SortTopological performs a topological sort and returns the sorted list of
+// arbitrary input types. If the graph contains a cycle, a *CycleError is returned.
+// The result is deterministic, corresponding to the post-order of a depth-first
+// search: vertices are visited in the order they were added and, upon visiting a
+// vertex, all vertices it depends upon are visited first (following its arcs in the
+// order they were added), the vertex itself being placed as soon as all of them have
+// been placed (vertices already placed are skipped). Hence, the same sequence of
+// additions always yields the same order, and if the order of addition already
+// satisfies all arcs, it is retained as is
+func (g *Graph[T]) SortTopological() (Objects[T], error) {
+	return g.sortTopological(nil)
 }
 
 ////////////////// Private methods /////////////////////////////////////////////
@@ -87,33 +270,103 @@ func (g *Graph[T]) find(obj T) (vertex[T], bool) {
 	return val, ok
 }
 
-// analyze recursively parses all graph vertices and their connections to other
-// vertices, constructing the topologically sorted list in the process
-func (g *Graph[T]) analyze(obj T, results, seen *list[T]) (err error) {
+// addArc adds a line / arc between two vertices that are known to exist
+func (g *Graph[T]) addArc(arcFrom, arcTo T) {
+	g.vertices[arcFrom].addArc(arcTo)
+	g.vertices[arcTo].addDependent(arcFrom)
+}
 
-	// Try to add the current vertex to the sorted list
-	if isNewElement := seen.add(obj); !isNewElement {
+// sortTopological performs a topological sort, optionally restricted to a set of
+// member vertices (ignoring all other vertices and all arcs pointing to them)
+func (g *Graph[T]) sortTopological(members *list[T]) (Objects[T], error) {
+	var (
+		results  = newList[T]()
+		visiting = make(map[T]int)
+	)
+
+	// Iteratively check each vertex for connected vertices and construct the
+	// sorted list
+	for _, obj := range g.order {
+		if !isMember(members, obj) {
+			continue
+		}
+		if err := g.analyze(obj, results, visiting, members); err != nil {
+			return nil, err
+		}
+	}
+
+	return results.elements, nil
+}
 
-		// Cycle detected, obtain conflicting vertex indices (we just addded the index,
-		// so we can forego the chekc for its existence)
-		index, _ := seen.findIndex(obj)
+// frame represents a vertex currently being visited during a depth-first search,
+// along with its connected vertices and the position of the next one to visit
+type frame[T comparable] struct {
+	obj  T
+	arcs Objects[T]
+	pos  int
+}
 
-		// Construct cycle
-		cycle := append(seen.elements[index:], obj)
+// analyze iteratively parses all graph vertices reachable from the provided vertex
+// (depth-first), adding each one to the sorted list as soon as all of its connected
+// vertices have been added. Vertices on the current path are tracked (along with
+// their position) in order to detect cycles, vertices already contained in the sorted
+// list are skipped, hence each vertex and arc is only processed once. If members are
+// provided, all other vertices are ignored
+func (g *Graph[T]) analyze(obj T, results *list[T], visiting map[T]int, members *list[T]) error {
 
-		// Return descriptive error indicating the cycle
-		return fmt.Errorf("cycle error: %s", cycle.String())
+	// Skip the vertex if it has already been sorted
+	if _, sorted := results.findIndex(obj); sorted {
+		return nil
 	}
 
-	// Recursively analyze next layer of graph
-	for _, arc := range g.vertices[obj].arcs() {
-		if err = g.analyze(arc, results, seen.clone()); err != nil {
-			return err
+	path := []frame[T]{{obj: obj, arcs: g.vertices[obj].arcs()}}
+	visiting[obj] = 0
+
+	for len(path) > 0 {
+		current := &path[len(path)-1]
+
+		// Descend into the next connected vertex (if any)
+		if current.pos < len(current.arcs) {
+			next := current.arcs[current.pos]
+			current.pos++
+
+			if _, sorted := results.findIndex(next); sorted || !isMember(members, next) {
+				continue
+			}
+
+			// Cycle detected, construct it from the current path
+			if index, isVisiting := visiting[next]; isVisiting {
+				cycle := make(Objects[T], 0, len(path)-index+1)
+				for _, f := range path[index:] {
+					cycle = append(cycle, f.obj)
+				}
+
+				// Return descriptive error indicating the cycle
+				return &CycleError[T]{Cycle: append(cycle, next)}
+			}
+
+			visiting[next] = len(path)
+			path = append(path, frame[T]{obj: next, arcs: g.vertices[next].arcs()})
+			continue
 		}
-	}
 
-	// Add the current vertex to the resulting list
-	results.add(obj)
+		// All connected vertices have been sorted, add the current vertex to the
+		// resulting list
+		delete(visiting, current.obj)
+		results.add(current.obj)
+		path = path[:len(path)-1]
+	}
 
 	return nil
 }
+
+// isMember determines if a vertex is contained in a set of member vertices (if no
+// set is provided, all vertices are considered members)
+func isMember[T comparable](members *list[T], obj T) bool {
+	if members == nil {
+		return true
+	}
+
+	_, found := members.findIndex(obj)
+	return found
+}
@@ -8,6 +8,9 @@
 package graph
 
 import (
+	"errors"
+	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -114,6 +117,219 @@ func TestGraphInteraction(t *testing.T) {
 	// Try failed addition of arcs
 	require.Error(t, graph.AddArc("d", "doesnotexist"))
 	require.Error(t, graph.AddArc("dontexist", "a"))
+
+	var vertexErr *VertexNotFoundError[string]
+	err = graph.AddArc("d", "doesnotexist")
+	require.ErrorIs(t, err, ErrVertexNotFound)
+	require.True(t, errors.As(err, &vertexErr))
+	require.Equal(t, "doesnotexist", vertexErr.Vertex)
+	require.Equal(t, Destination, vertexErr.Endpoint)
+	require.EqualError(t, err, "destination vertex doesnotexist not found in graph")
+
+	err = graph.AddArc("dontexist", "a")
+	require.ErrorIs(t, err, ErrVertexNotFound)
+	require.True(t, errors.As(err, &vertexErr))
+	require.Equal(t, "dontexist", vertexErr.Vertex)
+	require.Equal(t, Source, vertexErr.Endpoint)
+	require.EqualError(t, err, "source vertex dontexist not found in graph")
+
+	require.ErrorIs(t, graph.RemoveVertex("dontexist"), ErrVertexNotFound)
+	require.ErrorIs(t, graph.RemoveArc("a", "d"), ErrArcNotFound)
+	require.NotErrorIs(t, graph.RemoveArc("a", "d"), ErrVertexNotFound)
+}
+
+func TestGraphMissingVertices(t *testing.T) {
+	graph := New[string]()
+	require.Error(t, graph.AddArc("a", "b"))
+	require.Zero(t, graph.Len())
+
+	graph = New[string](AddMissingVertices())
+	graph.AddVertex("a")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("c", "a"))
+	require.Equal(t, Objects[string]{"a", "b", "c"}, graph.Vertices())
+	require.Equal(t, []Arc[string]{{"a", "b"}, {"c", "a"}}, graph.Arcs())
+
+	graph = New[string](IgnoreMissingVertices())
+	graph.AddVertex("a")
+	graph.AddVertex("b")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("a", "c"))
+	require.Nil(t, graph.AddArc("c", "a"))
+	require.Equal(t, Objects[string]{"a", "b"}, graph.Vertices())
+	require.Equal(t, []Arc[string]{{"a", "b"}}, graph.Arcs())
+
+	// The last option takes precedence
+	graph = New[string](IgnoreMissingVertices(), AddMissingVertices())
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Equal(t, 2, graph.Len())
+}
+
+func TestGraphSelfLoops(t *testing.T) {
+	graph := NewGraph("a", "b")
+	err := graph.AddArc("a", "a")
+	require.EqualError(t, err, "self-loop error: vertex a depends upon itself")
+	require.ErrorIs(t, err, ErrSelfLoop)
+
+	var selfLoopErr *SelfLoopError[string]
+	require.True(t, errors.As(err, &selfLoopErr))
+	require.Equal(t, "a", selfLoopErr.Vertex)
+	require.Zero(t, graph.ArcCount())
+
+	graph = New[string](IgnoreSelfLoops())
+	graph.AddVertex("a")
+	graph.AddVertex("b")
+	require.Nil(t, graph.AddArc("a", "a"))
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Equal(t, []Arc[string]{{"a", "b"}}, graph.Arcs())
+}
+
+func TestGraphSoftArcs(t *testing.T) {
+	graph := NewGraph("a", "b", "c", "d")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("b", "c"))
+
+	// Soft arcs are added if possible
+	require.True(t, graph.AddSoftArc("c", "d"))
+	require.True(t, graph.AddSoftArc("a", "c"))
+	require.True(t, graph.AddSoftArc("a", "b"))
+
+	// Soft arcs referring to missing vertices or closing a cycle are dropped
+	require.False(t, graph.AddSoftArc("a", "x"))
+	require.False(t, graph.AddSoftArc("x", "a"))
+	require.False(t, graph.AddSoftArc("d", "a"))
+	require.False(t, graph.AddSoftArc("d", "d"))
+	require.Equal(t, []Arc[string]{{"a", "b"}, {"a", "c"}, {"b", "c"}, {"c", "d"}}, graph.Arcs())
+
+	result, err := graph.SortTopological()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"d", "c", "b", "a"}, result)
+}
+
+func TestGraphAccessors(t *testing.T) {
+	graph := NewGraph("a", "b", "c", "d")
+	require.Nil(t, graph.AddArc("a", "c"))
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("b", "c"))
+	require.Nil(t, graph.AddArc("a", "c"))
+
+	require.Equal(t, 4, graph.Len())
+	require.Equal(t, 3, graph.ArcCount())
+	require.Equal(t, Objects[string]{"a", "b", "c", "d"}, graph.Vertices())
+	require.Equal(t, []Arc[string]{{"a", "c"}, {"a", "b"}, {"b", "c"}}, graph.Arcs())
+	require.Equal(t, "a -> c", graph.Arcs()[0].String())
+
+	require.Equal(t, Objects[string]{"c", "b"}, graph.Parents("a"))
+	require.Equal(t, Objects[string]{"a", "b"}, graph.Children("c"))
+	require.Empty(t, graph.Parents("c"))
+	require.Empty(t, graph.Children("a"))
+	require.Nil(t, graph.Parents("doesnotexist"))
+	require.Nil(t, graph.Children("doesnotexist"))
+
+	require.Equal(t, 0, graph.InDegree("a"))
+	require.Equal(t, 2, graph.OutDegree("a"))
+	require.Equal(t, 2, graph.InDegree("c"))
+	require.Equal(t, 0, graph.OutDegree("c"))
+	require.Equal(t, 0, graph.InDegree("d"))
+	require.Equal(t, 0, graph.OutDegree("d"))
+	require.Equal(t, 0, graph.InDegree("doesnotexist"))
+	require.Equal(t, 0, graph.OutDegree("doesnotexist"))
+
+	require.True(t, graph.HasVertex("a"))
+	require.False(t, graph.HasVertex("doesnotexist"))
+	require.True(t, graph.HasArc("a", "c"))
+	require.False(t, graph.HasArc("c", "a"))
+	require.False(t, graph.HasArc("doesnotexist", "a"))
+
+	// Modifying the returned lists must not affect the graph
+	graph.Vertices()[0] = "z"
+	graph.Parents("a")[0] = "z"
+	graph.Children("c")[0] = "z"
+	require.Equal(t, Objects[string]{"a", "b", "c", "d"}, graph.Vertices())
+	require.Equal(t, Objects[string]{"c", "b"}, graph.Parents("a"))
+	require.Equal(t, Objects[string]{"a", "b"}, graph.Children("c"))
+
+	// Removal is reflected accordingly
+	require.Nil(t, graph.RemoveVertex("b"))
+	require.Equal(t, 3, graph.Len())
+	require.Equal(t, 1, graph.ArcCount())
+	require.Equal(t, Objects[string]{"a"}, graph.Children("c"))
+	require.Equal(t, 1, graph.InDegree("c"))
+}
+
+func TestGraphRemoval(t *testing.T) {
+	graph := NewGraph("a", "b", "c", "d")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("b", "c"))
+	require.Nil(t, graph.AddArc("a", "c"))
+	require.Nil(t, graph.AddArc("d", "c"))
+	require.Nil(t, graph.AddArc("c", "a"))
+
+	// Try failed removal of vertex / arcs
+	var vertexErr *VertexNotFoundError[string]
+	require.True(t, errors.As(graph.RemoveVertex("doesnotexist"), &vertexErr))
+	require.Equal(t, "doesnotexist", vertexErr.Vertex)
+	require.EqualError(t, vertexErr, "vertex doesnotexist not found in graph")
+
+	var arcErr *ArcNotFoundError[string]
+	require.True(t, errors.As(graph.RemoveArc("b", "a"), &arcErr))
+	require.Equal(t, "b", arcErr.From)
+	require.Equal(t, "a", arcErr.To)
+	require.EqualError(t, arcErr, "arc b -> a not found in graph")
+	require.True(t, errors.As(graph.RemoveArc("doesnotexist", "a"), &arcErr))
+
+	// Remove the arc closing the cycle
+	require.Nil(t, graph.RemoveArc("c", "a"))
+	require.Error(t, graph.RemoveArc("c", "a"))
+	result, err := graph.SortTopological()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"c", "b", "a", "d"}, result)
+
+	// Remove a vertex with arcs from and to it
+	require.Nil(t, graph.RemoveVertex("c"))
+	require.Error(t, graph.RemoveVertex("c"))
+	require.Equal(t, Objects[string]{"a", "b", "d"}, graph.Vertices())
+	require.Equal(t, Objects[string]{"b"}, graph.Parents("a"))
+	require.Empty(t, graph.Parents("b"))
+	require.Empty(t, graph.Parents("d"))
+
+	// Re-adding the vertex does not restore any arcs
+	graph.AddVertex("c")
+	require.Equal(t, Objects[string]{"a", "b", "d", "c"}, graph.Vertices())
+	result, err = graph.SortTopological()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"b", "a", "d", "c"}, result)
+
+	// Remove a vertex with a dependent
+	require.Nil(t, graph.AddArc("a", "d"))
+	require.Nil(t, graph.RemoveVertex("d"))
+	require.Equal(t, Objects[string]{"b"}, graph.Parents("a"))
+}
+
+func TestSortTopologicalOrder(t *testing.T) {
+
+	// Vertices are placed in depth-first post-order: vertices are visited in the
+	// order they were added, all vertices a vertex depends upon being visited first
+	graph := NewGraph("a", "b", "c", "d", "e")
+	require.Nil(t, graph.AddArc("a", "d"))
+	require.Nil(t, graph.AddArc("b", "e"))
+	require.Nil(t, graph.AddArc("b", "c"))
+	require.Nil(t, graph.AddArc("e", "d"))
+
+	result, err := graph.SortTopological()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"d", "a", "e", "c", "b"}, result)
+
+	// An order of addition satisfying all arcs is retained
+	graph = NewGraph("d", "a", "e", "c", "b")
+	require.Nil(t, graph.AddArc("a", "d"))
+	require.Nil(t, graph.AddArc("b", "e"))
+	require.Nil(t, graph.AddArc("b", "c"))
+	require.Nil(t, graph.AddArc("e", "d"))
+
+	result, err = graph.SortTopological()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"d", "a", "e", "c", "b"}, result)
 }
 
 func TestGraphTable(t *testing.T) {
@@ -138,4 +354,486 @@ func TestGraphCyclic(t *testing.T) {
 
 	_, err := cyclicGraph.SortTopological()
 	require.ErrorContains(t, err, "cycle error")
+
+	var cycleErr *CycleError[string]
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, Objects[string]{"a", "b", "c", "a"}, cycleErr.Cycle)
+	require.Equal(t, "cycle error: a -> b -> c -> a", cycleErr.Error())
+}
+
+func TestFindCycles(t *testing.T) {
+	acyclicGraph := NewGraph("a", "b", "c")
+	require.Nil(t, acyclicGraph.AddArc("a", "b"))
+	require.Nil(t, acyclicGraph.AddArc("b", "c"))
+	require.Empty(t, acyclicGraph.FindCycles())
+
+	cyclicGraph := NewGraph("a", "b", "c", "d", "e", "f", "g")
+
+	// First cycle (with a shortcut): a -> b -> c -> d -> a, c -> a
+	require.Nil(t, cyclicGraph.AddArc("a", "b"))
+	require.Nil(t, cyclicGraph.AddArc("b", "c"))
+	require.Nil(t, cyclicGraph.AddArc("c", "d"))
+	require.Nil(t, cyclicGraph.AddArc("d", "a"))
+	require.Nil(t, cyclicGraph.AddArc("c", "a"))
+
+	// Second, independent cycle connected to the first one: f -> e -> f
+	require.Nil(t, cyclicGraph.AddArc("e", "f"))
+	require.Nil(t, cyclicGraph.AddArc("f", "e"))
+	require.Nil(t, cyclicGraph.AddArc("e", "a"))
+
+	// Self-references cannot be added
+	require.NotNil(t, cyclicGraph.AddArc("g", "g"))
+
+	require.Equal(t, []Objects[string]{
+		{"a", "b", "c", "a"},
+		{"e", "f", "e"},
+	}, cyclicGraph.FindCycles())
+}
+
+func TestFeedbackArcSet(t *testing.T) {
+	acyclicGraph := newRandomGraph(100, 3)
+	require.Empty(t, acyclicGraph.FeedbackArcSet())
+	exactArcs, err := acyclicGraph.FeedbackArcSetExact()
+	require.Nil(t, err)
+	require.Empty(t, exactArcs)
+
+	// Based on TestFindCycles()
+	cyclicGraph := NewGraph("a", "b", "c", "d", "e", "f", "g")
+	require.Nil(t, cyclicGraph.AddArc("a", "b"))
+	require.Nil(t, cyclicGraph.AddArc("b", "c"))
+	require.Nil(t, cyclicGraph.AddArc("c", "d"))
+	require.Nil(t, cyclicGraph.AddArc("d", "a"))
+	require.Nil(t, cyclicGraph.AddArc("c", "a"))
+	require.Nil(t, cyclicGraph.AddArc("e", "f"))
+	require.Nil(t, cyclicGraph.AddArc("f", "e"))
+	require.Nil(t, cyclicGraph.AddArc("e", "a"))
+
+	require.Equal(t, []Arc[string]{{"b", "c"}, {"f", "e"}}, cyclicGraph.FeedbackArcSet())
+	arcs, err := cyclicGraph.FeedbackArcSetExact()
+	require.Nil(t, err)
+	require.Equal(t, []Arc[string]{{"a", "b"}, {"e", "f"}}, arcs)
+
+	// Removing the arcs renders random cyclic graphs acyclic (the exact set never being
+	// larger than the heuristic one)
+	rnd := rand.New(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		graph := NewGraph[int]()
+		for j := 0; j < 12; j++ {
+			graph.AddVertex(j)
+		}
+		for j := 0; j < 30; j++ {
+			from, to := rnd.Intn(12), rnd.Intn(12)
+			if from != to {
+				require.Nil(t, graph.AddArc(from, to))
+			}
+		}
+
+		heuristic := graph.FeedbackArcSet()
+		exact, err := graph.FeedbackArcSetExact()
+		require.Nil(t, err)
+		require.LessOrEqual(t, len(exact), len(heuristic))
+
+		for _, arcs := range [][]Arc[int]{heuristic, exact} {
+			reduced := NewGraph(graph.Vertices()...)
+			for _, arc := range graph.Arcs() {
+				require.Nil(t, reduced.AddArc(arc.From, arc.To))
+			}
+			for _, arc := range arcs {
+				require.Nil(t, reduced.RemoveArc(arc.From, arc.To))
+			}
+			require.Empty(t, reduced.FindCycles())
+		}
+	}
+
+	// Components exceeding the size limit cannot be handled exactly
+	largeGraph := newChainGraph(30)
+	require.Nil(t, largeGraph.AddArc(29, 0))
+	require.Equal(t, []Arc[int]{{29, 0}}, largeGraph.FeedbackArcSet())
+	_, err = largeGraph.FeedbackArcSetExact()
+	require.ErrorIs(t, err, ErrGraphTooLarge)
+}
+
+func TestLevels(t *testing.T) {
+	graph := NewGraph[string]()
+	levels, err := graph.Levels()
+	require.Nil(t, err)
+	require.Empty(t, levels)
+
+	graph = NewGraph("a", "b", "c", "d", "e", "f")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("a", "c"))
+	require.Nil(t, graph.AddArc("c", "d"))
+	require.Nil(t, graph.AddArc("e", "d"))
+
+	levels, err = graph.Levels()
+	require.Nil(t, err)
+	require.Equal(t, []Objects[string]{
+		{"b", "d", "f"},
+		{"c", "e"},
+		{"a"},
+	}, levels)
+
+	require.Nil(t, graph.AddArc("d", "a"))
+	_, err = graph.Levels()
+	var cycleErr *CycleError[string]
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, Objects[string]{"a", "c", "d", "a"}, cycleErr.Cycle)
+}
+
+func TestReverse(t *testing.T) {
+	graph := NewGraph[string]()
+	levels, err := graph.LevelsReverse()
+	require.Nil(t, err)
+	require.Empty(t, levels)
+
+	graph = NewGraph("a", "b", "c", "d", "e", "f")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("a", "c"))
+	require.Nil(t, graph.AddArc("c", "d"))
+	require.Nil(t, graph.AddArc("e", "d"))
+
+	sorted, err := graph.SortTopologicalReverse()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"f", "e", "a", "c", "d", "b"}, sorted)
+
+	levels, err = graph.LevelsReverse()
+	require.Nil(t, err)
+	require.Equal(t, []Objects[string]{
+		{"a", "e", "f"},
+		{"b", "c"},
+		{"d"},
+	}, levels)
+
+	require.Nil(t, graph.AddArc("d", "a"))
+	_, err = graph.SortTopologicalReverse()
+	var cycleErr *CycleError[string]
+	require.True(t, errors.As(err, &cycleErr))
+	_, err = graph.LevelsReverse()
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, Objects[string]{"a", "c", "d", "a"}, cycleErr.Cycle)
+}
+
+func TestAncestorsDescendants(t *testing.T) {
+	graph := NewGraph("a", "b", "c", "d", "e", "f")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("a", "c"))
+	require.Nil(t, graph.AddArc("c", "d"))
+	require.Nil(t, graph.AddArc("e", "d"))
+	require.Nil(t, graph.AddArc("d", "f"))
+
+	ancestors, err := graph.Ancestors("a")
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"b", "f", "d", "c"}, ancestors)
+	ancestors, err = graph.AncestorsUpTo("a", 1)
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"b", "c"}, ancestors)
+	ancestors, err = graph.AncestorsUpTo("a", 2)
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"b", "d", "c"}, ancestors)
+	ancestors, err = graph.Ancestors("f")
+	require.Nil(t, err)
+	require.Empty(t, ancestors)
+
+	descendants, err := graph.Descendants("f")
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"d", "c", "a", "e"}, descendants)
+	descendants, err = graph.DescendantsUpTo("f", 2)
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"d", "c", "e"}, descendants)
+	descendants, err = graph.Descendants("a")
+	require.Nil(t, err)
+	require.Empty(t, descendants)
+
+	var vertexErr *VertexNotFoundError[string]
+	_, err = graph.Ancestors("doesnotexist")
+	require.True(t, errors.As(err, &vertexErr))
+	_, err = graph.Descendants("doesnotexist")
+	require.True(t, errors.As(err, &vertexErr))
+
+	// Cycles only affect the vertices connected to them
+	require.Nil(t, graph.AddArc("f", "c"))
+	var cycleErr *CycleError[string]
+	_, err = graph.Ancestors("a")
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, Objects[string]{"c", "d", "f", "c"}, cycleErr.Cycle)
+	_, err = graph.Descendants("f")
+	require.True(t, errors.As(err, &cycleErr))
+	ancestors, err = graph.Ancestors("b")
+	require.Nil(t, err)
+	require.Empty(t, ancestors)
+}
+
+func TestSubgraphFor(t *testing.T) {
+	graph := NewGraph("a", "b", "c", "d", "e", "f", "g", "h")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("a", "c"))
+	require.Nil(t, graph.AddArc("c", "d"))
+	require.Nil(t, graph.AddArc("e", "d"))
+	require.Nil(t, graph.AddArc("d", "f"))
+
+	// Unrelated cycle
+	require.Nil(t, graph.AddArc("g", "h"))
+	require.Nil(t, graph.AddArc("h", "g"))
+
+	result, err := graph.SubgraphFor("c")
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"f", "d", "c"}, result)
+	result, err = graph.SubgraphFor("e", "b", "d")
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"b", "f", "d", "e"}, result)
+	result, err = graph.SubgraphFor()
+	require.Nil(t, err)
+	require.Empty(t, result)
+
+	var vertexErr *VertexNotFoundError[string]
+	_, err = graph.SubgraphFor("a", "doesnotexist")
+	require.True(t, errors.As(err, &vertexErr))
+	require.Equal(t, "doesnotexist", vertexErr.Vertex)
+
+	var cycleErr *CycleError[string]
+	_, err = graph.SubgraphFor("a", "h")
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, Objects[string]{"g", "h", "g"}, cycleErr.Cycle)
+}
+
+func TestTransitive(t *testing.T) {
+	graph := NewGraph("a", "b", "c", "d", "e")
+	require.Nil(t, graph.AddArc("a", "c"))
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("b", "c"))
+	require.Nil(t, graph.AddArc("c", "d"))
+	require.Nil(t, graph.AddArc("a", "d"))
+	require.Nil(t, graph.AddArc("e", "d"))
+
+	reduction, err := graph.TransitiveReduction()
+	require.Nil(t, err)
+	require.Equal(t, graph.Vertices(), reduction.Vertices())
+	require.Equal(t, []Arc[string]{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"e", "d"}}, reduction.Arcs())
+	require.Equal(t, Objects[string]{"a"}, reduction.Children("b"))
+
+	closure := graph.TransitiveClosure()
+	require.Equal(t, graph.Vertices(), closure.Vertices())
+	require.Equal(t, []Arc[string]{
+		{"a", "c"}, {"a", "b"}, {"a", "d"},
+		{"b", "c"}, {"b", "d"},
+		{"c", "d"},
+		{"e", "d"},
+	}, closure.Arcs())
+	require.Equal(t, Objects[string]{"a", "b", "c", "e"}, closure.Children("d"))
+
+	// The original graph remains unchanged
+	require.Equal(t, 6, graph.ArcCount())
+
+	// Cycles do not cause vertices to depend upon themselves in the transitive closure
+	require.Nil(t, graph.AddArc("d", "b"))
+	_, err = graph.TransitiveReduction()
+	var cycleErr *CycleError[string]
+	require.True(t, errors.As(err, &cycleErr))
+	closure = graph.TransitiveClosure()
+	require.False(t, closure.HasArc("b", "b"))
+	require.True(t, closure.HasArc("d", "c"))
+	require.False(t, closure.HasArc("a", "a"))
+
+	// Removing a feedback arc set renders the transitive closure of a cyclic graph
+	// acyclic
+	cyclicGraph := NewGraph(1, 2)
+	require.Nil(t, cyclicGraph.AddArc(1, 2))
+	require.Nil(t, cyclicGraph.AddArc(2, 1))
+	cyclicClosure := cyclicGraph.TransitiveClosure()
+	require.Equal(t, []Arc[int]{{1, 2}, {2, 1}}, cyclicClosure.Arcs())
+	for _, arc := range cyclicClosure.FeedbackArcSet() {
+		require.Nil(t, cyclicClosure.RemoveArc(arc.From, arc.To))
+	}
+	_, err = cyclicClosure.SortTopological()
+	require.Nil(t, err)
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	graph := NewGraph("a", "b", "c", "d", "e", "f", "g")
+	require.Nil(t, graph.AddArc("a", "b"))
+	require.Nil(t, graph.AddArc("b", "c"))
+	require.Nil(t, graph.AddArc("c", "a"))
+	require.Nil(t, graph.AddArc("c", "d"))
+	require.Nil(t, graph.AddArc("e", "f"))
+	require.Nil(t, graph.AddArc("f", "e"))
+	require.Nil(t, graph.AddArc("d", "f"))
+	require.Nil(t, graph.AddArc("g", "a"))
+
+	components := graph.StronglyConnectedComponents()
+	require.Equal(t, []Objects[string]{
+		{"a", "b", "c"},
+		{"d"},
+		{"e", "f"},
+		{"g"},
+	}, components)
+
+	condensation, condensedComponents := graph.Condensation()
+	require.Equal(t, components, condensedComponents)
+	require.Equal(t, Objects[int]{0, 1, 2, 3}, condensation.Vertices())
+	require.Equal(t, []Arc[int]{{0, 1}, {1, 2}, {3, 0}}, condensation.Arcs())
+
+	sorted, err := condensation.SortTopological()
+	require.Nil(t, err)
+	require.Equal(t, Objects[int]{2, 1, 0, 3}, sorted)
+
+	// An acyclic graph yields one component per vertex
+	components = NewGraph("a", "b").StronglyConnectedComponents()
+	require.Equal(t, []Objects[string]{{"a"}, {"b"}}, components)
+}
+
+func TestSortTopologicalFunc(t *testing.T) {
+	graph := NewGraph(5, 3, 1, 4, 2, 6)
+	require.Nil(t, graph.AddArc(1, 6))
+	require.Nil(t, graph.AddArc(3, 4))
+
+	ascending := func(a, b int) bool { return a < b }
+	result, err := graph.SortTopologicalFunc(ascending)
+	require.Nil(t, err)
+	require.Equal(t, Objects[int]{2, 4, 3, 5, 6, 1}, result)
+
+	// Ties are broken by the order of addition
+	result, err = graph.SortTopologicalFunc(func(a, b int) bool { return a%2 < b%2 })
+	require.Nil(t, err)
+	require.Equal(t, Objects[int]{4, 2, 6, 5, 3, 1}, result)
+
+	// Without dependencies, the result is sorted according to the function
+	result, err = NewGraph(5, 3, 1, 4, 2, 6).SortTopologicalFunc(ascending)
+	require.Nil(t, err)
+	require.Equal(t, Objects[int]{1, 2, 3, 4, 5, 6}, result)
+
+	// Cycles are reported in the same way as for SortTopological()
+	require.Nil(t, graph.AddArc(4, 3))
+	_, err = graph.SortTopologicalFunc(ascending)
+	var cycleErr *CycleError[int]
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, Objects[int]{3, 4, 3}, cycleErr.Cycle)
+}
+
+func TestSortTopologicalStable(t *testing.T) {
+	graph := NewGraph("a", "b", "c", "d", "e", "f")
+	require.Nil(t, graph.AddArc("b", "e"))
+	require.Nil(t, graph.AddArc("a", "d"))
+
+	result, err := graph.SortTopologicalStable()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"c", "d", "a", "e", "b", "f"}, result)
+
+	// In contrast, the depth-first search moves the dependencies in front
+	result, err = graph.SortTopological()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"d", "a", "e", "b", "c", "f"}, result)
+
+	// An order already satisfying all arcs is retained
+	graph = NewGraph("e", "b", "d", "a", "c")
+	require.Nil(t, graph.AddArc("b", "e"))
+	require.Nil(t, graph.AddArc("a", "d"))
+	result, err = graph.SortTopologicalStable()
+	require.Nil(t, err)
+	require.Equal(t, Objects[string]{"e", "b", "d", "a", "c"}, result)
+
+	// Cycles are reported in the same way as for SortTopological()
+	require.Nil(t, graph.AddArc("d", "a"))
+	_, err = graph.SortTopologicalStable()
+	var cycleErr *CycleError[string]
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, Objects[string]{"d", "a", "d"}, cycleErr.Cycle)
+}
+
+func BenchmarkSortTopological(b *testing.B) {
+	for _, n := range []int{10, 1000, 100000} {
+		b.Run(fmt.Sprintf("chain_%d", n), func(b *testing.B) {
+			benchmarkSortTopological(b, newChainGraph(n))
+		})
+		b.Run(fmt.Sprintf("random_%d", n), func(b *testing.B) {
+			benchmarkSortTopological(b, newRandomGraph(n, 4))
+		})
+	}
+}
+
+func benchmarkSortTopological(b *testing.B, graph *Graph[int]) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := graph.SortTopological(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFeedbackArcSet(b *testing.B) {
+	for _, n := range []int{10, 1000, 100000} {
+		b.Run(fmt.Sprintf("cycle_%d", n), func(b *testing.B) {
+			graph := newChainGraph(n)
+			if err := graph.AddArc(n-1, 0); err != nil {
+				b.Fatal(err)
+			}
+			benchmarkFeedbackArcSet(b, graph)
+		})
+		b.Run(fmt.Sprintf("random_%d", n), func(b *testing.B) {
+			benchmarkFeedbackArcSet(b, newRandomCyclicGraph(n, 3))
+		})
+	}
+}
+
+func benchmarkFeedbackArcSet(b *testing.B, graph *Graph[int]) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		graph.FeedbackArcSet()
+	}
+}
+
+// newChainGraph constructs a graph with n vertices, each one depending upon its successor
+func newChainGraph(n int) *Graph[int] {
+	graph := NewGraph[int]()
+	for i := 0; i < n; i++ {
+		graph.AddVertex(i)
+	}
+	for i := 0; i < n-1; i++ {
+		if err := graph.AddArc(i, i+1); err != nil {
+			panic(err)
+		}
+	}
+
+	return graph
+}
+
+// newRandomGraph constructs an acyclic graph with n vertices, each one depending
+// upon up to nArcs randomly chosen successors
+func newRandomGraph(n, nArcs int) *Graph[int] {
+	rnd := rand.New(rand.NewSource(42))
+	graph := NewGraph[int]()
+	for i := 0; i < n; i++ {
+		graph.AddVertex(i)
+	}
+	for i := 0; i < n-1; i++ {
+		for j := 0; j < nArcs; j++ {
+			if err := graph.AddArc(i, i+1+rnd.Intn(n-i-1)); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	return graph
+}
+
+// newRandomCyclicGraph constructs a (most likely cyclic) graph with n vertices, each
+// one depending upon up to nArcs randomly chosen other vertices
+func newRandomCyclicGraph(n, nArcs int) *Graph[int] {
+	rnd := rand.New(rand.NewSource(42))
+	graph := NewGraph[int]()
+	for i := 0; i < n; i++ {
+		graph.AddVertex(i)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < nArcs; j++ {
+			if to := rnd.Intn(n); to != i {
+				if err := graph.AddArc(i, to); err != nil {
+					panic(err)
+				}
+			}
+		}
+	}
+
+	return graph
 }
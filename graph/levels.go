@@ -0,0 +1,98 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+import "slices"
+
+// SortTopologicalReverse performs a topological sort and returns the sorted list of
+// arbitrary input types in reverse order, i.e. each vertex is placed before all
+// vertices it depends upon (e.g. for teardown). It is the exact reverse of the result
+// of SortTopological(), cycles are reported in the same way
+func (g *Graph[T]) SortTopologicalReverse() (Objects[T], error) {
+	sorted, err := g.SortTopological()
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(sorted)
+
+	return sorted, nil
+}
+
+// Levels performs a topological sort and returns the vertices grouped into levels /
+// generations: Each level only contains vertices whose arcs all point to vertices
+// in preceding levels, hence all vertices of a level can be processed concurrently
+// once all preceding levels have been processed. Each vertex is placed in the
+// earliest possible level, vertices within a level are ordered by the order they
+// were added. If the graph contains a cycle, a *CycleError is returned
+func (g *Graph[T]) Levels() ([]Objects[T], error) {
+
+	// Determine the topological order first (also detecting potential cycles)
+	sorted, err := g.SortTopological()
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine the level of each vertex, which is one above the highest level of
+	// all vertices it depends upon (all of which precede it in the sorted list)
+	levels := make(map[T]int, len(sorted))
+	for _, obj := range sorted {
+		level := 0
+		for _, arc := range g.vertices[obj].arcs() {
+			level = max(level, levels[arc]+1)
+		}
+		levels[obj] = level
+	}
+
+	return g.group(levels), nil
+}
+
+// LevelsReverse performs a topological sort and returns the vertices grouped into
+// levels / generations for reverse processing (e.g. for parallel teardown): Each
+// level only contains vertices whose dependent vertices (i.e. all vertices with arcs
+// pointing to them) are contained in preceding levels. Each vertex is placed in the
+// earliest possible level, vertices within a level are ordered by the order they were
+// added. Cycles are reported in the same way as for Levels()
+func (g *Graph[T]) LevelsReverse() ([]Objects[T], error) {
+
+	// Determine the topological order first (also detecting potential cycles)
+	sorted, err := g.SortTopological()
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine the level of each vertex, which is one above the highest level of
+	// all vertices depending upon it (all of which succeed it in the sorted list, hence
+	// have been processed beforehand when iterating backwards)
+	levels := make(map[T]int, len(sorted))
+	for i := len(sorted) - 1; i >= 0; i-- {
+		obj := sorted[i]
+		for _, arc := range g.vertices[obj].arcs() {
+			levels[arc] = max(levels[arc], levels[obj]+1)
+		}
+	}
+
+	return g.group(levels), nil
+}
+
+////////////////// Private methods /////////////////////////////////////////////
+
+// group arranges all vertices according to their assigned level (in order of addition)
+func (g *Graph[T]) group(levels map[T]int) []Objects[T] {
+
+	nLevels := 0
+	for _, obj := range g.order {
+		nLevels = max(nLevels, levels[obj]+1)
+	}
+
+	result := make([]Objects[T], nLevels)
+	for _, obj := range g.order {
+		result[levels[obj]] = append(result[levels[obj]], obj)
+	}
+
+	return result
+}
@@ -7,6 +7,8 @@
 
 package graph
 
+import "slices"
+
 // list is a generic structure holding a sorted array of connected vertices and
 // is used to determine the correct ordering and detect cycles
 type list[T comparable] struct {
@@ -35,18 +37,24 @@ func (s *list[T]) add(obj T) bool {
 	return !exists
 }
 
-func (s *list[T]) clone() *list[T] {
+// remove indicates if the element exists and conditionally removes it (retaining the
+// order of all other elements)
+func (s *list[T]) remove(obj T) bool {
 
-	// Pre-allocate result map with the correct number of elements
-	listCopy := &list[T]{make(map[T]int, len(s.indices)), make(Objects[T], len(s.elements))}
+	// Check if the element exists in the list
+	index, exists := s.indices[obj]
+	if !exists {
+		return false
+	}
 
-	// Populate copy elements / indices
-	for k, v := range s.elements {
-		listCopy.elements[k] = v
-		listCopy.indices[v] = s.indices[v]
+	// Remove the element and update the indices of all subsequent elements
+	delete(s.indices, obj)
+	s.elements = slices.Delete(s.elements, index, index+1)
+	for i := index; i < len(s.elements); i++ {
+		s.indices[s.elements[i]] = i
 	}
 
-	return listCopy
+	return true
 }
 
 func (s *list[T]) findIndex(obj T) (int, bool) {
@@ -28,3 +28,11 @@ func (l Objects[T]) String() string {
 
 	return objString
 }
+
+// clone returns a copy of the list
+func (l Objects[T]) clone() Objects[T] {
+	listCopy := make(Objects[T], len(l))
+	copy(listCopy, l)
+
+	return listCopy
+}
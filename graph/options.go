@@ -0,0 +1,60 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+// Option represents a functional option to configure the behavior of a graph
+type Option func(*config)
+
+// missingVertexPolicy denotes how arcs referring to missing vertices are handled
+type missingVertexPolicy int
+
+const (
+	missingVerticesFail missingVertexPolicy = iota
+	missingVerticesAdd
+	missingVerticesIgnore
+)
+
+// config denotes the configuration of a graph (as defined by the provided options)
+type config struct {
+	missingVertices missingVertexPolicy
+	ignoreSelfLoops bool
+}
+
+// newConfig returns a new configuration with all provided options applied
+func newConfig(opts ...Option) config {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// AddMissingVertices causes AddArc() to implicitly add all vertices referred to by
+// an arc that do not exist yet (instead of returning an error)
+func AddMissingVertices() Option {
+	return func(cfg *config) {
+		cfg.missingVertices = missingVerticesAdd
+	}
+}
+
+// IgnoreMissingVertices causes AddArc() to silently ignore all arcs referring to a
+// vertex that does not exist (instead of returning an error)
+func IgnoreMissingVertices() Option {
+	return func(cfg *config) {
+		cfg.missingVertices = missingVerticesIgnore
+	}
+}
+
+// IgnoreSelfLoops causes AddArc() to silently ignore all arcs from a vertex to itself
+// (instead of returning a *SelfLoopError)
+func IgnoreSelfLoops() Option {
+	return func(cfg *config) {
+		cfg.ignoreSelfLoops = true
+	}
+}
@@ -0,0 +1,123 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+import "container/heap"
+
+// SortTopologicalFunc performs a topological sort and returns the sorted list of
+// arbitrary input types, using the provided function to prioritize among vertices:
+// At each step, the smallest vertex (according to less) of all vertices whose arcs
+// solely point to already sorted vertices is placed next, ties being broken by the
+// order the vertices were added. If the graph contains a cycle, a *CycleError is
+// returned
+func (g *Graph[T]) SortTopologicalFunc(less func(a, b T) bool) (Objects[T], error) {
+	return g.sortPriority(less)
+}
+
+// SortTopologicalStable performs a topological sort and returns the sorted list of
+// arbitrary input types, keeping the vertices as close to the order they were added as
+// possible: At each step, the earliest added vertex of all vertices whose arcs solely
+// point to already sorted vertices is placed next (yielding the lexicographically
+// smallest topological order with respect to the order of addition). Hence, vertices
+// are only moved if required by an arc: if the order of addition already satisfies
+// all arcs, it is retained, and vertices without any arcs are never placed behind a
+// vertex that was added after them. If the graph contains a cycle, a *CycleError is
+// returned
+func (g *Graph[T]) SortTopologicalStable() (Objects[T], error) {
+	return g.sortPriority(nil)
+}
+
+////////////////// Private methods /////////////////////////////////////////////
+
+// sortPriority performs a topological sort using Kahn's algorithm, selecting the next
+// vertex among all vertices whose dependencies have been fulfilled according to the
+// provided function (if any) and their position
+func (g *Graph[T]) sortPriority(less func(a, b T) bool) (Objects[T], error) {
+
+	positions := g.positions()
+	ready := &priorityQueue[T]{less: func(a, b T) bool {
+		if less != nil {
+			if less(a, b) {
+				return true
+			}
+			if less(b, a) {
+				return false
+			}
+		}
+		return positions[a] < positions[b]
+	}}
+
+	// Determine the number of unfulfilled dependencies of each vertex, vertices without
+	// any dependencies are ready right away
+	remaining := make(map[T]int, len(g.order))
+	for _, obj := range g.order {
+		remaining[obj] = len(g.vertices[obj].arcs())
+		if remaining[obj] == 0 {
+			heap.Push(ready, obj)
+		}
+	}
+
+	// Successively place the highest priority vertex, updating all vertices depending
+	// upon it
+	results := make(Objects[T], 0, len(g.order))
+	for ready.Len() > 0 {
+		obj := heap.Pop(ready).(T)
+		results = append(results, obj)
+
+		for _, dependent := range g.vertices[obj].dependents() {
+			if remaining[dependent]--; remaining[dependent] == 0 {
+				heap.Push(ready, dependent)
+			}
+		}
+	}
+
+	// If not all vertices could be placed, the remaining ones contain a cycle, which
+	// is determined in the same way as for SortTopological()
+	if len(results) < len(g.order) {
+		members := newList[T]()
+		for _, obj := range g.order {
+			if remaining[obj] > 0 {
+				members.add(obj)
+			}
+		}
+
+		_, err := g.sortTopological(members)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// priorityQueue is a generic min-heap of vertices (implementing heap.Interface)
+type priorityQueue[T comparable] struct {
+	elements Objects[T]
+	less     func(a, b T) bool
+}
+
+func (q *priorityQueue[T]) Len() int {
+	return len(q.elements)
+}
+
+func (q *priorityQueue[T]) Less(i, j int) bool {
+	return q.less(q.elements[i], q.elements[j])
+}
+
+func (q *priorityQueue[T]) Swap(i, j int) {
+	q.elements[i], q.elements[j] = q.elements[j], q.elements[i]
+}
+
+func (q *priorityQueue[T]) Push(x any) {
+	q.elements = append(q.elements, x.(T))
+}
+
+func (q *priorityQueue[T]) Pop() any {
+	last := q.elements[len(q.elements)-1]
+	q.elements = q.elements[:len(q.elements)-1]
+
+	return last
+}
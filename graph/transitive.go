@@ -0,0 +1,78 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package graph
+
+// TransitiveClosure returns a new graph (the reachability graph) containing all
+// vertices of the graph and an arc from each vertex to every other vertex it depends
+// upon, directly or indirectly. Since a graph cannot contain arcs from a vertex to
+// itself (cf. AddArc()), vertices that are part of a cycle (and hence reach themselves)
+// do not depend upon themselves. The vertices retain their order, the arcs of each
+// vertex are ordered by distance (in the order they were reached)
+func (g *Graph[T]) TransitiveClosure() *Graph[T] {
+
+	closure := NewGraph(g.order...)
+	for _, obj := range g.order {
+		for _, arc := range g.reachable(obj, 0, vertex[T].arcs).elements {
+			if arc != obj {
+				closure.addArc(obj, arc)
+			}
+		}
+	}
+
+	return closure
+}
+
+// TransitiveReduction returns a new graph containing all vertices of the graph but
+// only the minimal set of arcs required to retain its reachability, i.e. every arc
+// that is implied by other arcs (e.g. a -> c in the presence of a -> b -> c) is
+// removed. Vertices and arcs retain their order. Since the transitive reduction of a
+// cyclic graph is not unique, a *CycleError is returned if the graph contains a cycle
+func (g *Graph[T]) TransitiveReduction() (*Graph[T], error) {
+
+	// Make sure the graph is acyclic
+	if _, err := g.SortTopological(); err != nil {
+		return nil, err
+	}
+
+	reduction := NewGraph(g.order...)
+	reachableFrom := make(map[T]*list[T])
+	for _, obj := range g.order {
+		arcs := g.vertices[obj].arcs()
+		for _, arc := range arcs {
+			if !g.isImplied(arc, arcs, reachableFrom) {
+				reduction.addArc(obj, arc)
+			}
+		}
+	}
+
+	return reduction, nil
+}
+
+////////////////// Private methods /////////////////////////////////////////////
+
+// isImplied determines if an arc to the provided vertex is implied by any of the
+// other provided arcs (of the same source vertex), i.e. if it is reachable via any of
+// them. Reachable vertices are cached across calls
+func (g *Graph[T]) isImplied(arc T, arcs Objects[T], reachableFrom map[T]*list[T]) bool {
+	for _, other := range arcs {
+		if other == arc {
+			continue
+		}
+
+		reached, cached := reachableFrom[other]
+		if !cached {
+			reached = g.reachable(other, 0, vertex[T].arcs)
+			reachableFrom[other] = reached
+		}
+		if _, found := reached.findIndex(arc); found {
+			return true
+		}
+	}
+
+	return false
+}
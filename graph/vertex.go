@@ -7,31 +7,46 @@
 
 package graph
 
-// vertex represents a node / vertex of a graph
-type vertex[T comparable] map[T]struct{}
+// vertex represents a node / vertex of a graph, keeping track of its lines / arcs
+// (and the ones pointing to it) in the order they were added
+type vertex[T comparable] struct {
+	arcList       *list[T]
+	dependentList *list[T]
+}
 
 // newVertex returns a new vertex (constructor)
 func newVertex[T comparable]() vertex[T] {
-	return make(vertex[T])
+	return vertex[T]{arcList: newList[T](), dependentList: newList[T]()}
 }
 
 // addArc creates a new line / arc to the graph
 func (v vertex[T]) addArc(arc T) {
-	v[arc] = struct{}{}
+	v.arcList.add(arc)
+}
+
+// removeArc removes a line / arc from the graph and indicates if it existed
+func (v vertex[T]) removeArc(arc T) bool {
+	return v.arcList.remove(arc)
 }
 
-// arcs returns a list of all lines / arcs a graph contains
+// arcs returns a list of all lines / arcs a graph contains (in the order they were
+// added)
 func (v vertex[T]) arcs() Objects[T] {
+	return v.arcList.elements
+}
 
-	// Pre-allocate the list of arcs with the correct number of elements
-	list := make(Objects[T], len(v))
-	pos := 0
+// addDependent registers a vertex with a line / arc pointing to this vertex
+func (v vertex[T]) addDependent(obj T) {
+	v.dependentList.add(obj)
+}
 
-	// Populate the list of arcs
-	for k := range v {
-		list[pos] = k
-		pos++
-	}
+// removeDependent unregisters a vertex with a line / arc pointing to this vertex
+func (v vertex[T]) removeDependent(obj T) {
+	v.dependentList.remove(obj)
+}
 
-	return list
+// dependents returns a list of all vertices with a line / arc pointing to this vertex
+// (in the order the arcs were added)
+func (v vertex[T]) dependents() Objects[T] {
+	return v.dependentList.elements
 }
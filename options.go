@@ -0,0 +1,99 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package topo
+
+// Option represents a functional option to configure the behavior of a sort
+type Option func(*config)
+
+// config denotes the configuration of a sort (as defined by the provided options)
+type config struct {
+	reportAllCycles bool
+	groupCycles     bool
+	preserveOrder   bool
+	keepDuplicates  bool
+	ignoreMissing   bool
+	ignoreSelfLoops bool
+	maxDepth        int
+}
+
+// newConfig returns a new configuration with all provided options applied
+func newConfig(opts ...Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// ReportAllCycles causes a failing sort to report one representative cycle for each
+// cyclic part of the dependencies at once (joined via errors.Join()) instead of only
+// returning the first one that was encountered
+func ReportAllCycles() Option {
+	return func(cfg *config) {
+		cfg.reportAllCycles = true
+	}
+}
+
+// GroupCycles causes Sort() to treat all elements that are part of a common cycle
+// (i.e. of the same strongly connected component) as a single unit instead of failing:
+// Such elements are placed next to each other (retaining their original order) and
+// the units are sorted topologically (cf. SortComponents())
+func GroupCycles() Option {
+	return func(cfg *config) {
+		cfg.groupCycles = true
+	}
+}
+
+// PreserveOrder causes Sort() to keep the elements as close to their original order
+// as possible: At each step, the earliest element (in the original slice) of all
+// elements whose dependencies have already been placed is placed next. Hence, elements
+// are only moved if required by a dependency: if the original slice already satisfies
+// all dependencies, it remains unchanged, and elements without any dependencies are
+// never placed behind an element following them in the original slice (cf.
+// graph.Graph.SortTopologicalStable())
+func PreserveOrder() Option {
+	return func(cfg *config) {
+		cfg.preserveOrder = true
+	}
+}
+
+// KeepDuplicates causes a sort to accept data containing the same element more than
+// once (instead of returning a *DuplicateElementError): All occurrences of an element
+// are placed next to each other in the sorted output
+func KeepDuplicates() Option {
+	return func(cfg *config) {
+		cfg.keepDuplicates = true
+	}
+}
+
+// IgnoreMissingElements causes a sort to silently drop all dependencies referring to an
+// element that is not contained in the data (e.g. an optional element that is absent)
+// instead of returning an error
+func IgnoreMissingElements() Option {
+	return func(cfg *config) {
+		cfg.ignoreMissing = true
+	}
+}
+
+// IgnoreSelfLoops causes a sort to silently drop all dependencies of an element upon
+// itself instead of returning an error (wrapping a *graph.SelfLoopError)
+func IgnoreSelfLoops() Option {
+	return func(cfg *config) {
+		cfg.ignoreSelfLoops = true
+	}
+}
+
+// MaxDepth limits transitive queries (such as Ancestors() or Descendants()) to the
+// provided depth, where a depth of one only yields direct dependencies / dependents
+// (by default or if depth <= 0, the depth is unlimited)
+func MaxDepth(depth int) Option {
+	return func(cfg *config) {
+		cfg.maxDepth = depth
+	}
+}
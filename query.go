@@ -0,0 +1,89 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package topo
+
+import "github.com/fako1024/topo/graph"
+
+// Ancestors returns all elements the provided element depends upon, directly or
+// indirectly (in topological order, excluding the element itself), solely based on
+// the provided dependencies. Elements without any dependencies have no ancestors. If
+// the element or any of its ancestors are part of a cycle, a *graph.CycleError is
+// returned. The depth of the query can be limited via the MaxDepth() option
+func Ancestors[T comparable](deps Dependencies[T], obj T, opts ...Option) (graph.Objects[T], error) {
+	return query(deps, obj, (*graph.Graph[T]).AncestorsUpTo, opts...)
+}
+
+// Descendants returns all elements depending upon the provided element, directly or
+// indirectly (in topological order, excluding the element itself), solely based on
+// the provided dependencies. Elements without any dependents have no descendants. If
+// the element or any of its descendants are part of a cycle, a *graph.CycleError is
+// returned. The depth of the query can be limited via the MaxDepth() option
+func Descendants[T comparable](deps Dependencies[T], obj T, opts ...Option) (graph.Objects[T], error) {
+	return query(deps, obj, (*graph.Graph[T]).DescendantsUpTo, opts...)
+}
+
+// RedundantDependencies determines all dependencies that are implied by other
+// dependencies (e.g. A depending upon C in the presence of A depending upon B and B
+// depending upon C) or that are duplicates of a preceding one, and hence can be removed
+// without changing the resulting order constraints. The redundant dependencies are
+// returned in the order they appear in. If the dependencies contain a cycle, a
+// *graph.CycleError is returned
+func RedundantDependencies[T comparable](deps Dependencies[T], opts ...Option) (Dependencies[T], error) {
+
+	cfg := newConfig(opts...)
+
+	// Construct the graph from all elements referenced by the dependencies
+	gr, err := newGraph(deps.elements(), deps, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	reduction, err := gr.TransitiveReduction()
+	if err != nil {
+		return nil, cycleError(cfg, gr, err)
+	}
+
+	// Every dependency not contained in the transitive reduction (and every repeated
+	// occurrence of one that is) is redundant
+	seen := make(map[Dependency[T]]struct{})
+	redundant := make(Dependencies[T], 0)
+	for _, dep := range deps {
+		if _, exists := seen[dep]; exists || !reduction.HasArc(dep.Child, dep.Parent) {
+			redundant = append(redundant, dep)
+		}
+		seen[dep] = struct{}{}
+	}
+
+	return redundant, nil
+}
+
+////////////////// Private functions ///////////////////////////////////////////
+
+// query performs a transitive query on the graph constructed from the dependencies
+func query[T comparable](deps Dependencies[T], obj T, fn func(*graph.Graph[T], T, int) (graph.Objects[T], error), opts ...Option) (graph.Objects[T], error) {
+
+	cfg := newConfig(opts...)
+
+	// Construct the graph from all elements referenced by the dependencies
+	gr, err := newGraph(deps.elements(), deps, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Elements not referenced by any dependency are not related to any other element
+	if !gr.HasVertex(obj) {
+		return graph.Objects[T]{}, nil
+	}
+
+	result, err := fn(gr, obj, cfg.maxDepth)
+	if err != nil {
+		return nil, cycleError(cfg, gr, err)
+	}
+
+	return result, nil
+}
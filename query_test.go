@@ -0,0 +1,84 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package topo
+
+import (
+	"testing"
+
+	"github.com/fako1024/topo/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAncestorsDescendants(t *testing.T) {
+
+	// Based on example_simple_test.go
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+	}
+
+	ancestors, err := Ancestors(stringDependencies, "B")
+	require.Nil(t, err)
+	require.Equal(t, graph.Objects[string]{"E", "A", "C", "D"}, ancestors)
+	ancestors, err = Ancestors(stringDependencies, "B", MaxDepth(1))
+	require.Nil(t, err)
+	require.Equal(t, graph.Objects[string]{"A", "C", "D"}, ancestors)
+	ancestors, err = Ancestors(stringDependencies, "F")
+	require.Nil(t, err)
+	require.Empty(t, ancestors)
+
+	descendants, err := Descendants(stringDependencies, "C")
+	require.Nil(t, err)
+	require.Equal(t, graph.Objects[string]{"D", "B"}, descendants)
+	descendants, err = Descendants(stringDependencies, "E", MaxDepth(1))
+	require.Nil(t, err)
+	require.Equal(t, graph.Objects[string]{"A"}, descendants)
+	descendants, err = Descendants(stringDependencies, "B")
+	require.Nil(t, err)
+	require.Empty(t, descendants)
+
+	// Cycles are reported in the same way as for Sort()
+	cyclicDependencies := append(stringDependencies, Dependency[string]{Child: "C", Parent: "B"})
+	_, err = Ancestors(cyclicDependencies, "B")
+	require.ErrorContains(t, err, "cycle error: B -> C -> B")
+	_, err = Descendants(cyclicDependencies, "E", ReportAllCycles())
+	require.ErrorContains(t, err, "cycle error: B -> C -> B")
+}
+
+func TestRedundantDependencies(t *testing.T) {
+
+	// Based on example_simple_test.go, with redundant dependencies
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "E"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+		{Child: "B", Parent: "A"},
+	}
+
+	redundant, err := RedundantDependencies(stringDependencies)
+	require.Nil(t, err)
+	require.Equal(t, Dependencies[string]{
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "E"},
+		{Child: "B", Parent: "A"},
+	}, redundant)
+
+	redundant, err = RedundantDependencies(stringDependencies[:2])
+	require.Nil(t, err)
+	require.Empty(t, redundant)
+
+	// Cycles are reported in the same way as for Sort()
+	_, err = RedundantDependencies(append(stringDependencies, Dependency[string]{Child: "C", Parent: "B"}))
+	require.ErrorContains(t, err, "cycle error: B -> C -> B")
+}
@@ -0,0 +1,61 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package reflectdeps
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrInvalidTag denotes that the struct tags of an element type are malformed or
+	// inconsistent (can be checked for via errors.Is() on any *TagError)
+	ErrInvalidTag = errors.New("invalid topo struct tag")
+
+	// ErrNilElement denotes that an element (or a nested struct containing its id) is a
+	// nil pointer or that its id is a nil interface, hence it cannot be identified
+	ErrNilElement = errors.New("element is nil")
+
+	// ErrNotComparable denotes that the value of an id or after field (of interface
+	// type) is not comparable and hence cannot be used as key
+	ErrNotComparable = errors.New("key value is not comparable")
+)
+
+// TagError is returned if the struct tags of an element type are malformed or
+// inconsistent, e.g. if a tag is unknown, if no (or more than one) id field exists or
+// if a tagged field has a type not matching the key type
+type TagError struct {
+
+	// Type denotes the element type
+	Type reflect.Type
+
+	// Field denotes the (dot-separated) path of the affected field, starting at the
+	// element type (empty if the error does not refer to a specific field)
+	Field string
+
+	// Tag denotes the value of the affected tag (if any)
+	Tag string
+
+	// Reason describes the problem
+	Reason string
+}
+
+// Error returns a descriptive string indicating the malformed tag
+func (e *TagError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("invalid topo struct tags on type %s: %s", e.Type, e.Reason)
+	}
+
+	return fmt.Sprintf("invalid topo struct tag %q on field %s.%s: %s", e.Tag, e.Type, e.Field, e.Reason)
+}
+
+// Is allows to match the error against ErrInvalidTag via errors.Is()
+func (e *TagError) Is(target error) bool {
+	return target == ErrInvalidTag
+}
@@ -0,0 +1,314 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+// Package reflectdeps extracts dependencies between structs from struct tags (using
+// reflection), allowing to sort a slice of structs without providing the dependencies
+// separately. Each struct is identified by the field tagged `topo:"id"` and depends
+// upon all elements identified by the fields tagged `topo:"after"`, e.g.:
+//
+//	type Job struct {
+//		Name     string   `topo:"id"`
+//		Requires []string `topo:"after"`
+//		Setup    string   `topo:"after"`
+//	}
+//
+// The id field must be of the key type, each after field must be of the key type or a
+// slice thereof (named types with the same underlying kind are converted), all of them
+// being comparable. Tagged fields may be contained in nested structs (or pointers to
+// structs), which are searched unless they are tagged `topo:"-"`. Empty strings and nil
+// interface values in after fields are ignored (allowing for optional dependencies),
+// all other values (including the zero value of the key type, e.g. 0) are considered
+// dependencies
+package reflectdeps
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fako1024/topo"
+)
+
+const (
+	tagName  = "topo"
+	tagID    = "id"
+	tagAfter = "after"
+	tagSkip  = "-"
+)
+
+// Dependencies extracts the dependencies declared by the struct tags of the provided
+// elements (in the order of the elements, followed by the order of their after fields
+// and values). The elements must be structs or pointers to structs. If the struct tags
+// are malformed, a *TagError is returned
+func Dependencies[K comparable, T any](items []T) (topo.Dependencies[K], error) {
+	_, deps, err := extract[K](items)
+	return deps, err
+}
+
+// Sort performs a topological sort on a slice of structs (sort in place) in the same
+// way as topo.SortBy(), using the id fields as keys and the dependencies declared by
+// the struct tags (cf. Dependencies()). Since the key type cannot be inferred, it has
+// to be provided explicitly, e.g. Sort[string](items). If the struct tags are
+// malformed, a *TagError is returned, all other errors are returned in the same way as
+// for topo.SortBy()
+func Sort[K comparable, T any](items []T, opts ...topo.Option) error {
+
+	keys, deps, err := extract[K](items)
+	if err != nil {
+		return err
+	}
+
+	// Sort the indices of the elements (based on their keys) and rearrange the
+	// original slice accordingly
+	indices := make([]int, len(items))
+	for i := range indices {
+		indices[i] = i
+	}
+	if err := topo.SortBy(indices, func(i int) K { return keys[i] }, deps, opts...); err != nil {
+		return err
+	}
+
+	sorted := make([]T, len(items))
+	for i, index := range indices {
+		sorted[i] = items[index]
+	}
+	copy(items, sorted)
+
+	return nil
+}
+
+////////////////// Private functions ///////////////////////////////////////////
+
+// field denotes a tagged field of a struct, referenced by the sequence of field indices
+// leading to it (potentially traversing nested structs)
+type field struct {
+	path    string
+	index   []int
+	isSlice bool
+}
+
+// value retrieves the value of the field from a struct (or pointer to a struct). If any
+// struct on the way is a nil pointer, false is returned
+func (f field) value(v reflect.Value) (reflect.Value, bool) {
+	for _, i := range f.index {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+
+	return v, true
+}
+
+// schema denotes the tagged fields of a struct type
+type schema struct {
+	id    field
+	after []field
+}
+
+// extract determines the keys of all elements and the dependencies declared by them
+func extract[K comparable, T any](items []T) ([]K, topo.Dependencies[K], error) {
+
+	s, err := parse(reflect.TypeFor[T](), reflect.TypeFor[K]())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]K, len(items))
+	for i, item := range items {
+		v, ok := s.id.value(reflect.ValueOf(&item).Elem())
+		if !ok {
+			return nil, nil, fmt.Errorf("element %d: %w", i, ErrNilElement)
+		}
+		if v.Kind() == reflect.Interface && v.IsNil() {
+			return nil, nil, fmt.Errorf("element %d: field %s: %w", i, s.id.path, ErrNilElement)
+		}
+		if !v.Comparable() {
+			return nil, nil, fmt.Errorf("element %d: field %s: %w", i, s.id.path, ErrNotComparable)
+		}
+		keys[i] = key[K](v)
+	}
+
+	var deps topo.Dependencies[K]
+	for i, item := range items {
+		for _, f := range s.after {
+			v, ok := f.value(reflect.ValueOf(&item).Elem())
+			if !ok {
+				continue
+			}
+
+			values := []reflect.Value{v}
+			if f.isSlice {
+				values = make([]reflect.Value, v.Len())
+				for j := range values {
+					values[j] = v.Index(j)
+				}
+			}
+
+			for _, val := range values {
+				if isEmpty(val) {
+					continue
+				}
+				if !val.Comparable() {
+					return nil, nil, fmt.Errorf("element %d: field %s: %w", i, f.path, ErrNotComparable)
+				}
+				deps = append(deps, topo.Dependency[K]{Child: keys[i], Parent: key[K](val)})
+			}
+		}
+	}
+
+	return keys, deps, nil
+}
+
+// parse determines the tagged fields of a struct type (or pointer to a struct type)
+func parse(typ, keyType reflect.Type) (*schema, error) {
+
+	structType := typ
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, &TagError{Type: typ, Reason: "element type is not a struct or pointer to a struct"}
+	}
+
+	var (
+		s       schema
+		idCount int
+	)
+	if err := walk(typ, structType, keyType, nil, "", map[reflect.Type]struct{}{structType: {}}, func(f field, tag string) error {
+		if tag == tagID {
+			if idCount++; idCount > 1 {
+				return &TagError{Type: typ, Field: f.path, Tag: tag, Reason: fmt.Sprintf("duplicate id field (already declared by %s)", s.id.path)}
+			}
+			if f.isSlice {
+				return &TagError{Type: typ, Field: f.path, Tag: tag, Reason: fmt.Sprintf("id field must be of type %s", keyType)}
+			}
+			s.id = f
+			return nil
+		}
+
+		s.after = append(s.after, f)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if idCount == 0 {
+		return nil, &TagError{Type: typ, Reason: fmt.Sprintf("no field tagged `%s:%q`", tagName, tagID)}
+	}
+
+	return &s, nil
+}
+
+// walk recursively parses all fields of a struct type, calling the provided function
+// for each tagged field. Nested structs (or pointers to structs) are traversed unless
+// they are tagged to be skipped (or have already been visited on the current path)
+func walk(typ, structType, keyType reflect.Type, index []int, prefix string, visited map[reflect.Type]struct{}, fn func(field, string) error) error {
+
+	for i := 0; i < structType.NumField(); i++ {
+		sf := structType.Field(i)
+		f := field{path: prefix + sf.Name, index: append(append([]int{}, index...), i)}
+
+		tag, tagged := sf.Tag.Lookup(tagName)
+		if !tagged {
+
+			// Traverse nested (exported) structs
+			nested := sf.Type
+			if nested.Kind() == reflect.Pointer {
+				nested = nested.Elem()
+			}
+			if nested.Kind() != reflect.Struct || !sf.IsExported() {
+				continue
+			}
+			if _, seen := visited[nested]; seen {
+				continue
+			}
+
+			visited[nested] = struct{}{}
+			if err := walk(typ, nested, keyType, f.index, f.path+".", visited, fn); err != nil {
+				return err
+			}
+			delete(visited, nested)
+
+			continue
+		}
+
+		switch strings.TrimSpace(tag) {
+		case tagSkip:
+			continue
+		case tagID, tagAfter:
+		default:
+			return &TagError{Type: typ, Field: f.path, Tag: tag, Reason: fmt.Sprintf("unknown tag (must be %q, %q or %q)", tagID, tagAfter, tagSkip)}
+		}
+		tag = strings.TrimSpace(tag)
+
+		if !sf.IsExported() {
+			return &TagError{Type: typ, Field: f.path, Tag: tag, Reason: "field is not exported"}
+		}
+
+		// Determine if the field holds a single key or a slice of keys
+		switch {
+		case sf.Type.Kind() == reflect.Slice && isKey(sf.Type.Elem(), keyType):
+			f.isSlice = true
+		case isKey(sf.Type, keyType):
+		case !isComparable(sf.Type):
+			return &TagError{Type: typ, Field: f.path, Tag: tag, Reason: fmt.Sprintf("field of type %s is not comparable", sf.Type)}
+		default:
+			return &TagError{Type: typ, Field: f.path, Tag: tag, Reason: fmt.Sprintf("field of type %s must be of type %s or []%s", sf.Type, keyType, keyType)}
+		}
+
+		if err := fn(f, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isKey determines if a type can be used as key (which requires it to be comparable,
+// even if the key type is an interface)
+func isKey(typ, keyType reflect.Type) bool {
+	if !typ.Comparable() {
+		return false
+	}
+
+	return typ.AssignableTo(keyType) || (typ.Kind() == keyType.Kind() && typ.ConvertibleTo(keyType))
+}
+
+// isComparable determines if a type (or the element type of a slice) is comparable
+func isComparable(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+
+	return typ.Comparable()
+}
+
+// isEmpty determines if a value is a nil interface or an empty string (or an interface
+// holding one)
+func isEmpty(v reflect.Value) bool {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+
+	return v.Kind() == reflect.String && v.Len() == 0
+}
+
+// key converts a value to the key type
+func key[K comparable](v reflect.Value) K {
+	if keyType := reflect.TypeFor[K](); v.Type() != keyType {
+		v = v.Convert(keyType)
+	}
+
+	return v.Interface().(K)
+}
@@ -0,0 +1,204 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package reflectdeps
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fako1024/topo"
+	"github.com/fako1024/topo/graph"
+	"github.com/stretchr/testify/require"
+)
+
+type name string
+
+type metadata struct {
+	Name   name     `topo:"id"`
+	Labels []string // untagged fields are ignored
+}
+
+type job struct {
+	Meta     metadata
+	Requires []string `topo:"after"`
+	Setup    string   `topo:"after"`
+	Env      map[string]string
+	Next     *job `topo:"-"`
+}
+
+func names[T any](items []T, id func(T) string) []string {
+	res := make([]string, len(items))
+	for i, item := range items {
+		res[i] = id(item)
+	}
+	return res
+}
+
+func TestSort(t *testing.T) {
+
+	// Based on example_simple_test.go
+	jobs := []job{
+		{Meta: metadata{Name: "A"}, Requires: []string{"E"}},
+		{Meta: metadata{Name: "B"}, Requires: []string{"A", "C"}, Setup: "D"},
+		{Meta: metadata{Name: "C"}},
+		{Meta: metadata{Name: "D"}, Setup: "C", Env: map[string]string{"key": "value"}},
+		{Meta: metadata{Name: "E"}},
+	}
+
+	deps, err := Dependencies[string](jobs)
+	require.Nil(t, err)
+	require.Equal(t, topo.Dependencies[string]{
+		{Child: "A", Parent: "E"},
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "D", Parent: "C"},
+	}, deps)
+
+	require.Nil(t, Sort[string](jobs))
+	require.Equal(t, []string{"E", "A", "C", "D", "B"}, names(jobs, func(j job) string { return string(j.Meta.Name) }))
+	require.Equal(t, "value", jobs[3].Env["key"])
+
+	// Pointers to structs, using the named key type
+	ptrs := []*job{
+		{Meta: metadata{Name: "X"}, Setup: "Y"},
+		{Meta: metadata{Name: "Y"}},
+	}
+	require.Nil(t, Sort[name](ptrs))
+	require.Equal(t, []string{"Y", "X"}, names(ptrs, func(j *job) string { return string(j.Meta.Name) }))
+
+	// Errors are returned in the same way as for topo.SortBy()
+	jobs = append(jobs, job{Meta: metadata{Name: "F"}, Setup: "Z"})
+	err = Sort[string](jobs)
+	require.ErrorIs(t, err, graph.ErrVertexNotFound)
+	require.Nil(t, Sort[string](jobs, topo.IgnoreMissingElements()))
+
+	// Elements that cannot be identified
+	require.ErrorIs(t, Sort[string]([]*job{nil}), ErrNilElement)
+}
+
+func TestSortIntKeys(t *testing.T) {
+	type task struct {
+		ID    int   `topo:"id"`
+		After []int `topo:"after"`
+	}
+
+	// Zero values are valid keys
+	tasks := []task{
+		{ID: 1, After: []int{0}},
+		{ID: 0},
+	}
+	deps, err := Dependencies[int](tasks)
+	require.Nil(t, err)
+	require.Equal(t, topo.Dependencies[int]{{Child: 1, Parent: 0}}, deps)
+
+	require.Nil(t, Sort[int](tasks))
+	require.Equal(t, []task{{ID: 0}, {ID: 1, After: []int{0}}}, tasks)
+}
+
+func TestSortNested(t *testing.T) {
+	type spec struct {
+		After []string `topo:"after"`
+	}
+	type task struct {
+		ID   string `topo:"id"`
+		Spec *spec
+	}
+
+	tasks := []task{
+		{ID: "A", Spec: &spec{After: []string{"B"}}},
+		{ID: "B"},
+	}
+	require.Nil(t, Sort[string](tasks))
+	require.Equal(t, []string{"B", "A"}, names(tasks, func(t task) string { return t.ID }))
+}
+
+func TestTagErrors(t *testing.T) {
+
+	type unknownTag struct {
+		ID    string `topo:"id"`
+		After string `topo:"before"`
+	}
+	_, err := Dependencies[string]([]unknownTag{})
+	require.EqualError(t, err, `invalid topo struct tag "before" on field reflectdeps.unknownTag.After: unknown tag (must be "id", "after" or "-")`)
+
+	var tagErr *TagError
+	require.True(t, errors.As(err, &tagErr))
+	require.ErrorIs(t, err, ErrInvalidTag)
+	require.Equal(t, "After", tagErr.Field)
+
+	type missingID struct {
+		Name string
+	}
+	_, err = Dependencies[string]([]missingID{})
+	require.EqualError(t, err, "invalid topo struct tags on type reflectdeps.missingID: no field tagged `topo:\"id\"`")
+
+	type duplicateID struct {
+		ID   string `topo:"id"`
+		Meta metadata
+	}
+	_, err = Dependencies[string]([]duplicateID{})
+	require.EqualError(t, err, `invalid topo struct tag "id" on field reflectdeps.duplicateID.Meta.Name: duplicate id field (already declared by ID)`)
+
+	type wrongType struct {
+		ID    string `topo:"id"`
+		After int    `topo:"after"`
+	}
+	_, err = Dependencies[string]([]wrongType{})
+	require.EqualError(t, err, `invalid topo struct tag "after" on field reflectdeps.wrongType.After: field of type int must be of type string or []string`)
+
+	type unexported struct {
+		ID    string `topo:"id"`
+		after string `topo:"after"`
+	}
+	_, err = Dependencies[string]([]unexported{{after: "x"}})
+	require.EqualError(t, err, `invalid topo struct tag "after" on field reflectdeps.unexported.after: field is not exported`)
+
+	type notComparable struct {
+		ID map[string]string `topo:"id"`
+	}
+	err = Sort[any]([]notComparable{{}})
+	require.EqualError(t, err, `invalid topo struct tag "id" on field reflectdeps.notComparable.ID: field of type map[string]string is not comparable`)
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	type notComparableValue struct {
+		ID    string `topo:"id"`
+		After any    `topo:"after"`
+	}
+	err = Sort[any]([]notComparableValue{{ID: "A", After: map[string]string{}}})
+	require.EqualError(t, err, "element 0: field After: key value is not comparable")
+	require.ErrorIs(t, err, ErrNotComparable)
+
+	_, err = Dependencies[string]([]string{"A"})
+	require.EqualError(t, err, "invalid topo struct tags on type string: element type is not a struct or pointer to a struct")
+}
+
+func TestSortInterfaceKeys(t *testing.T) {
+	type task struct {
+		ID    any   `topo:"id"`
+		After []any `topo:"after"`
+		Setup any   `topo:"after"`
+	}
+
+	// Nil values in after fields are ignored
+	tasks := []task{
+		{ID: "A", After: []any{nil, 1}},
+		{ID: 1, After: []any{nil}},
+	}
+	deps, err := Dependencies[any](tasks)
+	require.Nil(t, err)
+	require.Equal(t, topo.Dependencies[any]{{Child: "A", Parent: 1}}, deps)
+
+	require.Nil(t, Sort[any](tasks))
+	require.Equal(t, []any{1, "A"}, []any{tasks[0].ID, tasks[1].ID})
+
+	// Elements with a nil id cannot be identified
+	err = Sort[any]([]task{{ID: "A"}, {}})
+	require.EqualError(t, err, "element 1: field ID: element is nil")
+	require.ErrorIs(t, err, ErrNilElement)
+}
@@ -10,6 +10,7 @@ package topo
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/fako1024/topo/graph"
 )
@@ -35,39 +36,370 @@ func (d Dependency[T]) String() string {
 	return fmt.Sprintf("%v depends upon %v", d.Child, d.Parent)
 }
 
+// elements returns all elements referenced by the dependencies (in order of their
+// first occurrence)
+func (d Dependencies[T]) elements() graph.Objects[T] {
+	seen := make(map[T]struct{})
+	elements := make(graph.Objects[T], 0)
+	for _, dep := range d {
+		for _, obj := range []T{dep.Child, dep.Parent} {
+			if _, exists := seen[obj]; !exists {
+				seen[obj] = struct{}{}
+				elements = append(elements, obj)
+			}
+		}
+	}
+
+	return elements
+}
+
 // Sort performs a topological sort on a slice and constructs a directed graph (using the
 // dependency constraints) and finally converts back the resulting object list to the
-// original slice (sort in place)
-func Sort[T comparable](data graph.Objects[T], deps Dependencies[T]) (err error) {
+// original slice (sort in place). If the dependencies contain a cycle, a
+// *graph.CycleError is returned, if the slice contains an element more than once, a
+// *DuplicateElementError is returned (cf. KeepDuplicates()) and if a dependency refers
+// to an element not contained in the slice or to an element depending upon itself, a
+// *DependencyError (wrapping a *graph.VertexNotFoundError or *graph.SelfLoopError) is
+// returned. The behavior can optionally be adapted by providing one or more Options.
+// The sort is deterministic, corresponding to the post-order of a depth-first search:
+// elements are visited in the order of the original slice and, upon visiting an
+// element, all elements it depends upon are visited first (in the order of deps), the
+// element itself being placed as soon as all of them have been placed (cf.
+// graph.Graph.SortTopological()). If the original slice already satisfies all
+// dependencies, it remains unchanged
+func Sort[T comparable](data graph.Objects[T], deps Dependencies[T], opts ...Option) (err error) {
 
-	// In case there are no dependencies, return immediately without action
+	cfg := newConfig(opts...)
+
+	// In case there are no dependencies, return immediately without action (unless
+	// the data contains duplicates, which are supposed to be rejected)
 	if len(deps) == 0 {
+		if !cfg.keepDuplicates {
+			return duplicateElement(data)
+		}
 		return nil
 	}
 
+	// Construct the graph from the data and dependencies
+	var gr *graph.Graph[T]
+	if gr, err = newGraph(data, deps, cfg); err != nil {
+		return
+	}
+
+	return sortGraph(data, gr, cfg)
+}
+
+// SortSoft performs a topological sort on a slice in the same way as Sort() (sort in
+// place), taking into account both hard (required) and soft (optional) dependencies:
+// Hard dependencies are treated in the same way as for Sort(), whereas soft dependencies
+// referring to an element not contained in the slice are ignored and soft dependencies
+// that would close a cycle (after adding all hard ones and all preceding soft ones) are
+// dropped. The dropped soft dependencies are returned (in the order they appear in) and
+// should be treated as warnings. Errors are returned in the same way as for Sort(), hence
+// a cycle among hard dependencies is still an error. For other kinds of sorts (e.g.
+// levels) or for an execution via exec.Run(), soft dependencies can be added to a
+// graph.Graph directly via AddSoftArc(). Each soft dependency requires a search of the
+// graph, hence the effort grows with the product of the number of soft dependencies and
+// the size of the graph
+func SortSoft[T comparable](data graph.Objects[T], hard, soft Dependencies[T], opts ...Option) (dropped Dependencies[T], err error) {
+
+	cfg := newConfig(opts...)
+
+	// Construct the graph from the data and hard dependencies
+	gr, err := newGraph(data, hard, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add all soft dependencies (if possible)
+	for _, dep := range soft {
+		if !gr.HasVertex(dep.Child) || !gr.HasVertex(dep.Parent) {
+			continue
+		}
+		if !gr.AddSoftArc(dep.Child, dep.Parent) {
+			dropped = append(dropped, dep)
+		}
+	}
+
+	return dropped, sortGraph(data, gr, cfg)
+}
+
+// SortBreakingCycles performs a topological sort on a slice in the same way as Sort()
+// (sort in place), but instead of failing in case the dependencies contain cycles, it
+// removes a small set of dependencies breaking all cycles beforehand (cf.
+// graph.Graph.FeedbackArcSet()). The removed dependencies are returned (in the order
+// they appear in, including all duplicates) and should be treated as warnings. All other
+// errors are returned in the same way as for Sort()
+func SortBreakingCycles[T comparable](data graph.Objects[T], deps Dependencies[T], opts ...Option) (removed Dependencies[T], err error) {
+
+	cfg := newConfig(opts...)
+
+	// Construct the graph from the data and dependencies
+	gr, err := newGraph(data, deps, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove all arcs required to break all cycles
+	arcs := make(map[graph.Arc[T]]struct{})
+	for _, arc := range gr.FeedbackArcSet() {
+		if err := gr.RemoveArc(arc.From, arc.To); err != nil {
+			return nil, err
+		}
+		arcs[arc] = struct{}{}
+	}
+	for _, dep := range deps {
+		if _, isRemoved := arcs[graph.Arc[T]{From: dep.Child, To: dep.Parent}]; isRemoved {
+			removed = append(removed, dep)
+		}
+	}
+
+	return removed, sortGraph(data, gr, cfg)
+}
+
+// SortFunc performs a topological sort on a slice in the same way as Sort() (sort in
+// place), using the provided function to prioritize among elements: At each step, the
+// smallest element (according to less) of all elements whose dependencies have already
+// been placed is placed next, ties being broken by the order of the original slice.
+// Errors are returned in the same way as for Sort()
+func SortFunc[T comparable](data graph.Objects[T], deps Dependencies[T], less func(a, b T) bool, opts ...Option) error {
+
+	cfg := newConfig(opts...)
+
+	// Construct the graph from the data and dependencies
+	gr, err := newGraph(data, deps, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Perform topological sorting, return error if e.g. a cycle is found
+	result, err := gr.SortTopologicalFunc(less)
+	if err != nil {
+		return cycleError(cfg, gr, err)
+	}
+
+	// Restore potential duplicates and make sure the resulting slice contains the
+	// same number of elements as the original data
+	result = expand(result, duplicates(data))
+	if len(result) != len(data) {
+		return ErrUnexpectedMismatch
+	}
+
+	// Copy the sorted data back to the original slice
+	copy(data, result)
+
+	return nil
+}
+
+// SortBy performs a topological sort on a slice of arbitrary (not necessarily comparable)
+// elements in the same way as Sort() (sort in place), identifying each element by the
+// key returned by the provided function, which the dependencies refer to. Elements
+// sharing the same key are rejected with a *DuplicateElementError (unless the
+// KeepDuplicates() option is set, in which case they are placed next to each other,
+// retaining their original order). Errors are returned in the same way as for Sort()
+func SortBy[T any, K comparable](data []T, key func(T) K, deps Dependencies[K], opts ...Option) error {
+
+	// Determine the keys of all elements and sort them
+	keys := make(graph.Objects[K], len(data))
+	elements := make(map[K][]T, len(data))
+	for i, obj := range data {
+		keys[i] = key(obj)
+		elements[keys[i]] = append(elements[keys[i]], obj)
+	}
+	if err := Sort(keys, deps, opts...); err != nil {
+		return err
+	}
+
+	// Rearrange the original slice according to the sorted keys
+	for i, k := range keys {
+		data[i] = elements[k][0]
+		elements[k] = elements[k][1:]
+	}
+
+	return nil
+}
+
+// SortLevels performs a topological sort on a slice (using the dependency constraints)
+// and returns its elements grouped into levels / generations: Each level only contains
+// elements whose dependencies are all contained in preceding levels, hence all elements
+// of a level can be processed concurrently once all preceding levels have been processed.
+// Elements within a level retain the order of the original slice, which itself remains
+// unchanged. Errors are returned in the same way as for Sort()
+func SortLevels[T comparable](data graph.Objects[T], deps Dependencies[T], opts ...Option) ([]graph.Objects[T], error) {
+
+	cfg := newConfig(opts...)
+
+	// Construct the graph from the data and dependencies
+	gr, err := newGraph(data, deps, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Perform topological sorting and grouping, return error if e.g. a cycle is found
+	levels, err := gr.Levels()
+	if err != nil {
+		return nil, cycleError(cfg, gr, err)
+	}
+	counts := duplicates(data)
+	for i := range levels {
+		levels[i] = expand(levels[i], counts)
+	}
+
+	return levels, nil
+}
+
+// SortReverse performs a topological sort on a slice in the same way as Sort(), but
+// in reverse order, i.e. each element is placed before all elements it depends upon
+// (e.g. for teardown). Errors are returned in the same way as for Sort()
+func SortReverse[T comparable](data graph.Objects[T], deps Dependencies[T], opts ...Option) error {
+	if err := Sort(data, deps, opts...); err != nil {
+		return err
+	}
+	slices.Reverse(data)
+
+	return nil
+}
+
+// SortLevelsReverse performs a topological sort on a slice (using the dependency
+// constraints) and returns its elements grouped into levels / generations for reverse
+// processing (e.g. for parallel teardown): Each level only contains elements whose
+// dependent elements are all contained in preceding levels. Elements within a level
+// retain the order of the original slice, which itself remains unchanged. Errors are
+// returned in the same way as for Sort()
+func SortLevelsReverse[T comparable](data graph.Objects[T], deps Dependencies[T], opts ...Option) ([]graph.Objects[T], error) {
+
+	cfg := newConfig(opts...)
+
+	// Construct the graph from the data and dependencies
+	gr, err := newGraph(data, deps, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Perform topological sorting and grouping, return error if e.g. a cycle is found
+	levels, err := gr.LevelsReverse()
+	if err != nil {
+		return nil, cycleError(cfg, gr, err)
+	}
+	counts := duplicates(data)
+	for i := range levels {
+		levels[i] = expand(levels[i], counts)
+	}
+
+	return levels, nil
+}
+
+// SortComponents performs a topological sort on a slice (using the dependency
+// constraints), treating all elements that are part of a common cycle (i.e. of the same
+// strongly connected component) as a single unit, and returns the sorted units: Each
+// unit only depends upon preceding units and contains either a single element or all
+// elements forming a cycle (retaining the order of the original slice, which itself
+// remains unchanged). Hence, no cycle error is returned, all other errors are returned
+// in the same way as for Sort()
+func SortComponents[T comparable](data graph.Objects[T], deps Dependencies[T], opts ...Option) ([]graph.Objects[T], error) {
+
+	cfg := newConfig(opts...)
+
+	// Construct the graph from the data and dependencies
+	gr, err := newGraph(data, deps, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := sortComponents(gr)
+	if err != nil {
+		return nil, err
+	}
+	counts := duplicates(data)
+	for i := range components {
+		components[i] = expand(components[i], counts)
+	}
+
+	return components, nil
+}
+
+// SortFor performs a topological sort on the provided targets and all elements they
+// depend upon, directly or indirectly, and returns the sorted list (leaving the original
+// slice unchanged). All other elements are disregarded, hence cycles not involving any
+// of the returned elements do not cause an error. If any of the targets is not contained
+// in the slice, a *graph.VertexNotFoundError is returned, all other errors are returned
+// in the same way as for Sort()
+func SortFor[T comparable](data graph.Objects[T], deps Dependencies[T], targets ...T) (graph.Objects[T], error) {
+
+	cfg := newConfig()
+
+	// Construct the graph from the data and dependencies
+	gr, err := newGraph(data, deps, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Perform topological sorting of the relevant part of the graph, return error if
+	// e.g. a cycle is found
+	return gr.SubgraphFor(targets...)
+}
+
+////////////////// Private functions ///////////////////////////////////////////
+
+// newGraph instantiates a new graph and adds all vertices (based on slice indices)
+// and arcs (based on the dependencies)
+func newGraph[T comparable](data graph.Objects[T], deps Dependencies[T], cfg *config) (*graph.Graph[T], error) {
+
 	// Instantiate a new (empty) graph
-	gr := graph.NewGraph[T]()
+	var graphOpts []graph.Option
+	if cfg.ignoreMissing {
+		graphOpts = append(graphOpts, graph.IgnoreMissingVertices())
+	}
+	if cfg.ignoreSelfLoops {
+		graphOpts = append(graphOpts, graph.IgnoreSelfLoops())
+	}
+	gr := graph.New[T](graphOpts...)
 
-	// Add all vertices (based on slice indices)
+	// Add all vertices (based on slice indices), duplicates are rejected unless they
+	// are supposed to be kept
+	if !cfg.keepDuplicates {
+		if err := duplicateElement(data); err != nil {
+			return nil, err
+		}
+	}
 	for i := 0; i < len(data); i++ {
 		gr.AddVertex(data[i])
 	}
 
 	// Add all dependencies (based on the enforced struct fields)
 	for i := 0; i < len(deps); i++ {
-		if err = gr.AddArc(deps[i].Child, deps[i].Parent); err != nil {
-			return
+		if err := gr.AddArc(deps[i].Child, deps[i].Parent); err != nil {
+			return nil, &DependencyError[T]{Index: i, Dependency: deps[i], Err: err}
 		}
 	}
 
-	// Perform topological sorting, return error if e.g. a cycle is found
+	return gr, nil
+}
+
+// sortGraph performs a topological sort of a graph constructed from a slice (according
+// to the configuration) and copies the result back to the original slice
+func sortGraph[T comparable](data graph.Objects[T], gr *graph.Graph[T], cfg *config) (err error) {
+
+	// Perform topological sorting, return error if e.g. a cycle is found (unless
+	// cycles are supposed to be grouped)
 	var result graph.Objects[T]
-	if result, err = gr.SortTopological(); err != nil {
-		return
+	if cfg.groupCycles {
+		var components []graph.Objects[T]
+		if components, err = sortComponents(gr); err != nil {
+			return
+		}
+		result = slices.Concat(components...)
+	} else if cfg.preserveOrder {
+		if result, err = gr.SortTopologicalStable(); err != nil {
+			return cycleError(cfg, gr, err)
+		}
+	} else if result, err = gr.SortTopological(); err != nil {
+		return cycleError(cfg, gr, err)
 	}
 
-	// Sanity check to make sure the resulting slice contains the same number of
-	// elements as the original data
+	// Restore potential duplicates and make sure the resulting slice contains the
+	// same number of elements as the original data
+	result = expand(result, duplicates(data))
 	if len(result) != len(data) {
 		return ErrUnexpectedMismatch
 	}
@@ -77,3 +409,93 @@ func Sort[T comparable](data graph.Objects[T], deps Dependencies[T]) (err error)
 
 	return
 }
+
+// duplicateElement returns a *DuplicateElementError for the first element contained
+// more than once in the data (or nil if there is none)
+func duplicateElement[T comparable](data graph.Objects[T]) error {
+
+	indices := make(map[T]int, len(data))
+	for i, obj := range data {
+		if first, exists := indices[obj]; exists {
+			return &DuplicateElementError[T]{Element: obj, First: first, Second: i}
+		}
+		indices[obj] = i
+	}
+
+	return nil
+}
+
+// duplicates determines the number of occurrences of all elements contained more than
+// once in the data (returning nil if there are none)
+func duplicates[T comparable](data graph.Objects[T]) map[T]int {
+
+	counts := make(map[T]int, len(data))
+	for _, obj := range data {
+		counts[obj]++
+	}
+
+	// Nothing to do in case there are no duplicates
+	if len(counts) == len(data) {
+		return nil
+	}
+
+	return counts
+}
+
+// expand restores all duplicate elements in a sorted list (placing them next to each
+// other)
+func expand[T comparable](sorted graph.Objects[T], duplicates map[T]int) graph.Objects[T] {
+
+	if duplicates == nil {
+		return sorted
+	}
+
+	expanded := make(graph.Objects[T], 0, len(sorted))
+	for _, obj := range sorted {
+		for i := 0; i < duplicates[obj]; i++ {
+			expanded = append(expanded, obj)
+		}
+	}
+
+	return expanded
+}
+
+// sortComponents sorts the strongly connected components of a graph topologically
+func sortComponents[T comparable](gr *graph.Graph[T]) ([]graph.Objects[T], error) {
+
+	// Sort the condensation of the graph (which is always acyclic)
+	condensation, components := gr.Condensation()
+	order, err := condensation.SortTopological()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]graph.Objects[T], len(order))
+	for i, component := range order {
+		result[i] = components[component]
+	}
+
+	return result, nil
+}
+
+// cycleError returns the error of a failed sort. If configured and the error denotes
+// a cycle, all cycles contained in the graph are returned (joined into a single error)
+func cycleError[T comparable](cfg *config, gr *graph.Graph[T], err error) error {
+
+	if !cfg.reportAllCycles {
+		return err
+	}
+
+	var cycleErr *graph.CycleError[T]
+	if !errors.As(err, &cycleErr) {
+		return err
+	}
+
+	cycles := gr.FindCycles()
+	errs := make([]error, len(cycles))
+	for i, cycle := range cycles {
+		errs[i] = &graph.CycleError[T]{Cycle: cycle}
+	}
+
+	return errors.Join(errs...)
+}
@@ -8,8 +8,10 @@
 package topo
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/fako1024/topo/graph"
 	"github.com/stretchr/testify/require"
 )
 
@@ -111,6 +113,234 @@ func TestSortNoDeps(t *testing.T) {
 	require.EqualValues(t, allStrings, allStringsOld)
 }
 
+func TestSortDeterministic(t *testing.T) {
+
+	// Based on example_simple_test.go (cf. README.md)
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+	}
+
+	for run := 0; run < nRunsConsistency; run++ {
+		var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+		require.Nil(t, Sort(allStrings, stringDependencies))
+		require.Equal(t, []string{"E", "A", "C", "D", "B", "F", "G", "H"}, allStrings)
+	}
+
+	// The order of the dependencies determines the order of the parents
+	for run := 0; run < nRunsConsistency; run++ {
+		var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+		require.Nil(t, Sort(allStrings, []Dependency[string]{{Child: "B", Parent: "C"}, {Child: "B", Parent: "D"}}))
+		require.Equal(t, []string{"A", "C", "D", "B", "E", "F", "G", "H"}, allStrings)
+
+		allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+		require.Nil(t, Sort(allStrings, []Dependency[string]{{Child: "B", Parent: "D"}, {Child: "B", Parent: "C"}}))
+		require.Equal(t, []string{"A", "D", "C", "B", "E", "F", "G", "H"}, allStrings)
+	}
+
+	// Elements are placed in depth-first post-order, not necessarily right after the
+	// elements they depend upon
+	for run := 0; run < nRunsConsistency; run++ {
+		var allStrings = []string{"A", "B", "C"}
+		require.Nil(t, Sort(allStrings, []Dependency[string]{{Child: "A", Parent: "C"}, {Child: "B", Parent: "C"}}))
+		require.Equal(t, []string{"C", "A", "B"}, allStrings)
+
+		allStrings = []string{"A", "B", "C", "D"}
+		require.Nil(t, Sort(allStrings, []Dependency[string]{{Child: "A", Parent: "D"}, {Child: "B", Parent: "C"}}))
+		require.Equal(t, []string{"D", "A", "C", "B"}, allStrings)
+	}
+
+	// An already sorted slice remains unchanged
+	for run := 0; run < nRunsConsistency; run++ {
+		var allStrings = []string{"E", "C", "D", "A", "B", "F", "G", "H"}
+		require.Nil(t, Sort(allStrings, stringDependencies))
+		require.Equal(t, []string{"E", "C", "D", "A", "B", "F", "G", "H"}, allStrings)
+	}
+}
+
+func TestSortPreserveOrder(t *testing.T) {
+
+	// Based on example_simple_test.go
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+	}
+
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	require.Nil(t, Sort(allStrings, stringDependencies, PreserveOrder()))
+	require.Equal(t, []string{"C", "D", "E", "A", "B", "F", "G", "H"}, allStrings)
+
+	// An already sorted slice remains unchanged
+	allStrings = []string{"C", "E", "F", "A", "G", "D", "B", "H"}
+	require.Nil(t, Sort(allStrings, stringDependencies, PreserveOrder()))
+	require.Equal(t, []string{"C", "E", "F", "A", "G", "D", "B", "H"}, allStrings)
+
+	// Errors are reported in the same way as for Sort()
+	cyclicDependencies := append(stringDependencies, Dependency[string]{Child: "C", Parent: "B"})
+	require.ErrorContains(t, Sort(allStrings, cyclicDependencies, PreserveOrder()), "cycle error: C -> B -> C")
+}
+
+func TestSortFunc(t *testing.T) {
+
+	// Based on example_simple_test.go
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+	}
+
+	// Prioritize in reverse alphabetical order
+	descending := func(a, b string) bool { return a > b }
+
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	require.Nil(t, SortFunc(allStrings, stringDependencies, descending))
+	require.Equal(t, []string{"H", "G", "F", "E", "C", "D", "A", "B"}, allStrings)
+
+	// Without dependencies, the slice is sorted according to the function
+	allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	require.Nil(t, SortFunc(allStrings, nil, descending))
+	require.Equal(t, []string{"H", "G", "F", "E", "D", "C", "B", "A"}, allStrings)
+
+	// Errors are reported in the same way as for Sort()
+	allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	cyclicDependencies := append(stringDependencies, Dependency[string]{Child: "C", Parent: "B"})
+	require.ErrorContains(t, SortFunc(allStrings, cyclicDependencies, descending), "cycle error: B -> C -> B")
+	require.ErrorContains(t, SortFunc(allStrings, []Dependency[string]{{Child: "Z", Parent: "A"}}, descending), "source vertex Z not found in graph")
+}
+
+func TestSortLevels(t *testing.T) {
+
+	// List of all simple strings (remains unchanged)
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+
+	// Based on example_simple_test.go
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+	}
+
+	levels, err := SortLevels(allStrings, stringDependencies)
+	require.Nil(t, err)
+	require.Equal(t, []graph.Objects[string]{
+		{"C", "E", "F", "G", "H"},
+		{"A", "D"},
+		{"B"},
+	}, levels)
+	require.Equal(t, []string{"A", "B", "C", "D", "E", "F", "G", "H"}, allStrings)
+
+	// Without dependencies, all elements are part of a single level
+	levels, err = SortLevels(allStrings, nil)
+	require.Nil(t, err)
+	require.Equal(t, []graph.Objects[string]{allStrings}, levels)
+
+	// Cycles are reported in the same way as for Sort()
+	_, err = SortLevels(allStrings, append(stringDependencies, Dependency[string]{Child: "C", Parent: "B"}))
+	require.ErrorContains(t, err, "cycle error: B -> C -> B")
+	_, err = SortLevels(allStrings, []Dependency[string]{{Child: "Z", Parent: "A"}})
+	require.ErrorContains(t, err, "source vertex Z not found in graph")
+}
+
+func TestSortReverse(t *testing.T) {
+
+	// Based on example_simple_test.go
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+	}
+
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	require.Nil(t, SortReverse(allStrings, stringDependencies))
+	require.Equal(t, []string{"H", "G", "F", "B", "D", "C", "A", "E"}, allStrings)
+
+	allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	levels, err := SortLevelsReverse(allStrings, stringDependencies)
+	require.Nil(t, err)
+	require.Equal(t, []graph.Objects[string]{
+		{"B", "F", "G", "H"},
+		{"A", "D"},
+		{"C", "E"},
+	}, levels)
+
+	// Errors are reported in the same way as for Sort()
+	cyclicDependencies := append(stringDependencies, Dependency[string]{Child: "C", Parent: "B"})
+	require.ErrorContains(t, SortReverse(allStrings, cyclicDependencies), "cycle error: B -> C -> B")
+	_, err = SortLevelsReverse(allStrings, cyclicDependencies)
+	require.ErrorContains(t, err, "cycle error: B -> C -> B")
+	_, err = SortLevelsReverse(allStrings, []Dependency[string]{{Child: "Z", Parent: "A"}})
+	require.ErrorContains(t, err, "source vertex Z not found in graph")
+}
+
+func TestSortFor(t *testing.T) {
+
+	// List of all simple strings (remains unchanged)
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+
+	// Based on example_simple_test.go, with an unrelated cycle
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+		{Child: "G", Parent: "H"},
+		{Child: "H", Parent: "G"},
+	}
+
+	result, err := SortFor(allStrings, stringDependencies, "D", "A")
+	require.Nil(t, err)
+	require.Equal(t, graph.Objects[string]{"E", "A", "C", "D"}, result)
+	require.Equal(t, []string{"A", "B", "C", "D", "E", "F", "G", "H"}, allStrings)
+
+	_, err = SortFor(allStrings, stringDependencies, "B", "G")
+	require.ErrorContains(t, err, "cycle error: G -> H -> G")
+	_, err = SortFor(allStrings, stringDependencies, "Z")
+	var vertexErr *graph.VertexNotFoundError[string]
+	require.True(t, errors.As(err, &vertexErr))
+	_, err = SortFor(allStrings, []Dependency[string]{{Child: "Z", Parent: "A"}}, "A")
+	require.ErrorContains(t, err, "source vertex Z not found in graph")
+}
+
+func TestSortComponents(t *testing.T) {
+
+	// Based on example_simple_test.go, with an additional cycle
+	var stringCyclicDependencies = []Dependency[string]{
+		{"B", "A"},
+		{"B", "C"},
+		{"B", "D"},
+		{"A", "E"},
+		{"D", "C"},
+		{"C", "B"},
+	}
+
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	components, err := SortComponents(allStrings, stringCyclicDependencies)
+	require.Nil(t, err)
+	require.Equal(t, []graph.Objects[string]{
+		{"E"}, {"A"}, {"B", "C", "D"}, {"F"}, {"G"}, {"H"},
+	}, components)
+	require.Equal(t, []string{"A", "B", "C", "D", "E", "F", "G", "H"}, allStrings)
+
+	require.Nil(t, Sort(allStrings, stringCyclicDependencies, GroupCycles()))
+	require.Equal(t, []string{"E", "A", "B", "C", "D", "F", "G", "H"}, allStrings)
+
+	_, err = SortComponents(allStrings, []Dependency[string]{{Child: "Z", Parent: "A"}})
+	require.ErrorContains(t, err, "source vertex Z not found in graph")
+}
+
 func TestSortCyclic(t *testing.T) {
 
 	// List of all simple strings (to be sorted)
@@ -136,7 +366,97 @@ func TestSortCyclic(t *testing.T) {
 	}
 
 	// Perform topological sort
-	require.ErrorContains(t, Sort(allStrings, stringCyclicDependencies), "cycle error")
+	err := Sort(allStrings, stringCyclicDependencies)
+	require.ErrorContains(t, err, "cycle error")
+
+	// Extract the cycle
+	var cycleErr *graph.CycleError[string]
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, graph.Objects[string]{"B", "C", "B"}, cycleErr.Cycle)
+}
+
+func TestSortAllCycles(t *testing.T) {
+
+	// List of all simple strings (to be sorted)
+	var allStrings = []string{
+		"A",
+		"B",
+		"C",
+		"D",
+		"E",
+		"F",
+		"G",
+		"H",
+	}
+
+	// Based on example_simple_test.go, with two independent cycles
+	var stringCyclicDependencies = []Dependency[string]{
+		{"B", "A"},
+		{"B", "C"},
+		{"B", "D"},
+		{"A", "E"},
+		{"D", "C"},
+		{"C", "B"},
+		{"F", "G"},
+		{"G", "H"},
+		{"H", "F"},
+	}
+
+	// Perform topological sort, reporting all cycles
+	err := Sort(allStrings, stringCyclicDependencies, ReportAllCycles())
+	require.ErrorContains(t, err, "cycle error: B -> C -> B")
+	require.ErrorContains(t, err, "cycle error: F -> G -> H -> F")
+
+	joinedErr, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+	require.Len(t, joinedErr.Unwrap(), 2)
+	for _, err := range joinedErr.Unwrap() {
+		var cycleErr *graph.CycleError[string]
+		require.True(t, errors.As(err, &cycleErr))
+	}
+}
+
+func TestSortDuplicates(t *testing.T) {
+
+	// Based on example_simple_test.go
+	var stringDependencies = []Dependency[string]{
+		{Child: "B", Parent: "A"},
+		{Child: "B", Parent: "C"},
+		{Child: "B", Parent: "D"},
+		{Child: "A", Parent: "E"},
+		{Child: "D", Parent: "C"},
+	}
+
+	// List of all simple strings, containing duplicates
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "C", "A", "G", "H", "A"}
+
+	err := Sort(allStrings, stringDependencies)
+	require.EqualError(t, err, "duplicate element C at indices 2 and 6")
+
+	var duplicateErr *DuplicateElementError[string]
+	require.True(t, errors.As(err, &duplicateErr))
+	require.Equal(t, "C", duplicateErr.Element)
+	require.Equal(t, 2, duplicateErr.First)
+	require.Equal(t, 6, duplicateErr.Second)
+
+	_, err = SortLevels(allStrings, stringDependencies)
+	require.True(t, errors.As(err, &duplicateErr))
+
+	// Duplicates are rejected even without any dependencies
+	require.EqualError(t, Sort([]string{"A", "B", "A"}, nil), "duplicate element A at indices 0 and 2")
+	require.Nil(t, Sort([]string{"A", "B", "A"}, nil, KeepDuplicates()))
+
+	// Keep the duplicates next to each other
+	require.Nil(t, Sort(allStrings, stringDependencies, KeepDuplicates()))
+	require.Equal(t, []string{"E", "A", "A", "A", "C", "C", "D", "B", "F", "G", "H"}, allStrings)
+
+	levels, err := SortLevels([]string{"A", "B", "C", "D", "E", "F", "C", "A", "G", "H", "A"}, stringDependencies, KeepDuplicates())
+	require.Nil(t, err)
+	require.Equal(t, []graph.Objects[string]{
+		{"C", "C", "E", "F", "G", "H"},
+		{"A", "A", "A", "D"},
+		{"B"},
+	}, levels)
 }
 
 func TestSortNonExistVertex(t *testing.T) {
@@ -164,5 +484,186 @@ func TestSortNonExistVertex(t *testing.T) {
 	}
 
 	// Perform topological sort
-	require.ErrorContains(t, Sort(allStrings, stringNonExistVertexDependencies), "source vertex Z not found in graph")
+	err := Sort(allStrings, stringNonExistVertexDependencies)
+	require.ErrorContains(t, err, "source vertex Z not found in graph")
+	require.EqualError(t, err, "dependency 5 (Z depends upon B): source vertex Z not found in graph")
+	require.ErrorIs(t, err, graph.ErrVertexNotFound)
+
+	// Extract the offending dependency and the missing element
+	var depErr *DependencyError[string]
+	require.True(t, errors.As(err, &depErr))
+	require.Equal(t, 5, depErr.Index)
+	require.Equal(t, Dependency[string]{"Z", "B"}, depErr.Dependency)
+
+	var vertexErr *graph.VertexNotFoundError[string]
+	require.True(t, errors.As(err, &vertexErr))
+	require.Equal(t, "Z", vertexErr.Vertex)
+	require.Equal(t, graph.Source, vertexErr.Endpoint)
+
+	stringNonExistVertexDependencies[5] = Dependency[string]{"B", "Z"}
+	err = Sort(allStrings, stringNonExistVertexDependencies)
+	require.EqualError(t, err, "dependency 5 (B depends upon Z): destination vertex Z not found in graph")
+	require.True(t, errors.As(err, &vertexErr))
+	require.Equal(t, graph.Destination, vertexErr.Endpoint)
+}
+
+func TestSortIgnoreMissing(t *testing.T) {
+
+	// List of all simple strings (to be sorted)
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+
+	// Based on example_simple_test.go, with dependencies referring to absent elements
+	var stringDependencies = []Dependency[string]{
+		{"B", "A"},
+		{"B", "C"},
+		{"B", "Y"},
+		{"B", "D"},
+		{"A", "E"},
+		{"D", "C"},
+		{"Z", "B"},
+	}
+
+	require.ErrorContains(t, Sort(allStrings, stringDependencies), "destination vertex Y not found in graph")
+	require.Nil(t, Sort(allStrings, stringDependencies, IgnoreMissingElements()))
+	require.Equal(t, []string{"E", "A", "C", "D", "B", "F", "G", "H"}, allStrings)
+}
+
+func TestSortSelfLoops(t *testing.T) {
+
+	// List of all simple strings (to be sorted)
+	var allStrings = []string{"A", "B", "C", "D"}
+
+	// Dependencies containing an element depending upon itself
+	var stringDependencies = []Dependency[string]{
+		{"B", "A"},
+		{"C", "C"},
+		{"C", "B"},
+	}
+
+	err := Sort(allStrings, stringDependencies)
+	require.EqualError(t, err, "dependency 1 (C depends upon C): self-loop error: vertex C depends upon itself")
+	require.ErrorIs(t, err, graph.ErrSelfLoop)
+
+	var depErr *DependencyError[string]
+	require.True(t, errors.As(err, &depErr))
+	require.Equal(t, 1, depErr.Index)
+
+	require.Nil(t, Sort(allStrings, stringDependencies, IgnoreSelfLoops()))
+	require.Equal(t, []string{"A", "B", "C", "D"}, allStrings)
+	require.Empty(t, Validate(allStrings, stringDependencies, IgnoreSelfLoops()))
+}
+
+func TestSortBy(t *testing.T) {
+
+	// Elements that are not comparable (due to containing a map)
+	type service struct {
+		name string
+		env  map[string]string
+	}
+	services := []service{
+		{"A", map[string]string{"id": "1"}},
+		{"B", nil},
+		{"C", map[string]string{"id": "3"}},
+		{"D", nil},
+	}
+	key := func(s service) string {
+		return s.name
+	}
+	names := func() []string {
+		res := make([]string, len(services))
+		for i, s := range services {
+			res[i] = s.name
+		}
+		return res
+	}
+
+	var stringDependencies = []Dependency[string]{
+		{"A", "B"},
+		{"B", "D"},
+		{"C", "A"},
+	}
+
+	require.Nil(t, SortBy(services, key, stringDependencies))
+	require.Equal(t, []string{"D", "B", "A", "C"}, names())
+	require.Equal(t, "1", services[2].env["id"])
+
+	// Duplicate keys are rejected unless they are supposed to be kept
+	services = append(services, service{"B", map[string]string{"id": "5"}})
+	require.EqualError(t, SortBy(services, key, stringDependencies), "duplicate element B at indices 1 and 4")
+	require.EqualError(t, SortBy(services, key, nil), "duplicate element B at indices 1 and 4")
+	require.Nil(t, SortBy(services, key, stringDependencies, KeepDuplicates()))
+	require.Equal(t, []string{"D", "B", "B", "A", "C"}, names())
+	require.Nil(t, services[1].env)
+	require.Equal(t, "5", services[2].env["id"])
+
+	// Errors are returned in the same way as for Sort()
+	err := SortBy(services, key, Dependencies[string]{{"X", "A"}}, KeepDuplicates())
+	var depErr *DependencyError[string]
+	require.True(t, errors.As(err, &depErr))
+	require.ErrorIs(t, err, graph.ErrVertexNotFound)
+}
+
+func TestSortSoft(t *testing.T) {
+
+	// List of all simple strings (to be sorted)
+	var allStrings = []string{"A", "B", "C", "D", "E"}
+
+	// Based on example_simple_test.go
+	var hardDependencies = []Dependency[string]{
+		{"B", "A"},
+		{"B", "C"},
+		{"A", "E"},
+	}
+
+	// Soft dependencies, including ones referring to absent elements and ones
+	// closing a cycle
+	var softDependencies = []Dependency[string]{
+		{"C", "D"},
+		{"A", "X"},
+		{"E", "B"},
+		{"D", "C"},
+		{"C", "A"},
+	}
+
+	dropped, err := SortSoft(allStrings, hardDependencies, softDependencies)
+	require.Nil(t, err)
+	require.Equal(t, Dependencies[string]{{"E", "B"}, {"D", "C"}}, dropped)
+	require.Equal(t, []string{"E", "A", "D", "C", "B"}, allStrings)
+
+	// A cycle among hard dependencies is still an error
+	var cycleErr *graph.CycleError[string]
+	_, err = SortSoft(allStrings, append(hardDependencies, Dependency[string]{"E", "B"}), softDependencies)
+	require.True(t, errors.As(err, &cycleErr))
+}
+
+func TestSortBreakingCycles(t *testing.T) {
+
+	// List of all simple strings (to be sorted)
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+
+	// Based on example_simple_test.go, with additional cycles
+	var stringDependencies = []Dependency[string]{
+		{"B", "A"},
+		{"B", "C"},
+		{"B", "D"},
+		{"A", "E"},
+		{"D", "C"},
+		{"C", "B"},
+		{"G", "H"},
+		{"H", "G"},
+	}
+
+	removed, err := SortBreakingCycles(allStrings, stringDependencies)
+	require.Nil(t, err)
+	require.Equal(t, Dependencies[string]{{"C", "B"}, {"H", "G"}}, removed)
+	require.Equal(t, []string{"E", "A", "C", "D", "B", "F", "H", "G"}, allStrings)
+
+	// Acyclic dependencies are not affected
+	removed, err = SortBreakingCycles(allStrings, stringDependencies[:5])
+	require.Nil(t, err)
+	require.Empty(t, removed)
+
+	// All other errors are returned in the same way as for Sort()
+	_, err = SortBreakingCycles(allStrings, append(stringDependencies, Dependency[string]{"X", "A"}))
+	require.ErrorIs(t, err, graph.ErrVertexNotFound)
 }
@@ -0,0 +1,87 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package topo
+
+import "github.com/fako1024/topo/graph"
+
+// Validate checks a slice and its dependencies for all problems that would prevent
+// a sort (without actually sorting) and returns them at once, in the following order:
+//   - a *DuplicateElementError for each repeated occurrence of an element in the slice
+//   - for each dependency (in order): a *DuplicateDependencyError if it is a repeated
+//     occurrence of a preceding dependency, a *DependencyError wrapping a
+//     *graph.SelfLoopError if an element depends upon itself and a *DependencyError
+//     wrapping a *graph.VertexNotFoundError for each element not contained in the slice
+//   - a *graph.CycleError for one representative cycle of each cyclic part of the
+//     remaining dependencies (cf. graph.Graph.FindCycles())
+//
+// The KeepDuplicates(), IgnoreMissingElements() and IgnoreSelfLoops() options suppress
+// the respective problems. If no problems are found, nil is returned
+func Validate[T comparable](data graph.Objects[T], deps Dependencies[T], opts ...Option) []error {
+
+	var (
+		cfg  = newConfig(opts...)
+		errs []error
+	)
+
+	// Check for duplicate elements (adding all distinct elements to the graph)
+	gr := graph.NewGraph[T]()
+	elementIndices := make(map[T]int, len(data))
+	for i, obj := range data {
+		if first, exists := elementIndices[obj]; exists {
+			if !cfg.keepDuplicates {
+				errs = append(errs, &DuplicateElementError[T]{Element: obj, First: first, Second: i})
+			}
+			continue
+		}
+
+		elementIndices[obj] = i
+		gr.AddVertex(obj)
+	}
+
+	// Check each dependency (adding all valid ones to the graph)
+	depIndices := make(map[Dependency[T]]int, len(deps))
+	for i, dep := range deps {
+		if first, exists := depIndices[dep]; exists {
+			errs = append(errs, &DuplicateDependencyError[T]{Dependency: dep, First: first, Second: i})
+			continue
+		}
+		depIndices[dep] = i
+
+		if dep.Child == dep.Parent {
+			if cfg.ignoreSelfLoops {
+				continue
+			}
+			errs = append(errs, &DependencyError[T]{Index: i, Dependency: dep, Err: &graph.SelfLoopError[T]{Vertex: dep.Child}})
+			continue
+		}
+
+		if !gr.HasVertex(dep.Child) || !gr.HasVertex(dep.Parent) {
+			if cfg.ignoreMissing {
+				continue
+			}
+			if !gr.HasVertex(dep.Child) {
+				errs = append(errs, &DependencyError[T]{Index: i, Dependency: dep, Err: &graph.VertexNotFoundError[T]{Vertex: dep.Child, Endpoint: graph.Source}})
+			}
+			if !gr.HasVertex(dep.Parent) {
+				errs = append(errs, &DependencyError[T]{Index: i, Dependency: dep, Err: &graph.VertexNotFoundError[T]{Vertex: dep.Parent, Endpoint: graph.Destination}})
+			}
+			continue
+		}
+
+		if err := gr.AddArc(dep.Child, dep.Parent); err != nil {
+			errs = append(errs, &DependencyError[T]{Index: i, Dependency: dep, Err: err})
+		}
+	}
+
+	// Check for cycles among all valid dependencies
+	for _, cycle := range gr.FindCycles() {
+		errs = append(errs, &graph.CycleError[T]{Cycle: cycle})
+	}
+
+	return errs
+}
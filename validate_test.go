@@ -0,0 +1,71 @@
+////////////////////////////////////////////////////////////////////////////////
+// Copyright (c) 2017 by Fabian Kohn
+//
+// This source code is licensed under the Apache License, Version 2.0, found in
+// the LICENSE file in the root directory of this source tree.
+////////////////////////////////////////////////////////////////////////////////
+
+package topo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fako1024/topo/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+
+	// List of all simple strings, containing a duplicate
+	var allStrings = []string{"A", "B", "C", "D", "E", "F", "G", "H", "C"}
+
+	// Based on example_simple_test.go, with various problems
+	var stringDependencies = []Dependency[string]{
+		{"B", "A"},
+		{"B", "C"},
+		{"B", "D"},
+		{"A", "E"},
+		{"D", "C"},
+		{"F", "F"},
+		{"B", "A"},
+		{"Z", "Y"},
+		{"G", "Y"},
+		{"C", "B"},
+		{"G", "H"},
+		{"H", "G"},
+	}
+
+	errs := Validate(allStrings, stringDependencies)
+	require.Len(t, errs, 8)
+	require.EqualError(t, errs[0], "duplicate element C at indices 2 and 8")
+	require.EqualError(t, errs[1], "dependency 5 (F depends upon F): self-loop error: vertex F depends upon itself")
+	require.EqualError(t, errs[2], "duplicate dependency (B depends upon A) at indices 0 and 6")
+	require.EqualError(t, errs[3], "dependency 7 (Z depends upon Y): source vertex Z not found in graph")
+	require.EqualError(t, errs[4], "dependency 7 (Z depends upon Y): destination vertex Y not found in graph")
+	require.EqualError(t, errs[5], "dependency 8 (G depends upon Y): destination vertex Y not found in graph")
+	require.EqualError(t, errs[6], "cycle error: B -> C -> B")
+	require.EqualError(t, errs[7], "cycle error: G -> H -> G")
+
+	var (
+		duplicateErr    *DuplicateElementError[string]
+		duplicateDepErr *DuplicateDependencyError[string]
+		depErr          *DependencyError[string]
+		cycleErr        *graph.CycleError[string]
+	)
+	require.True(t, errors.As(errs[0], &duplicateErr))
+	require.True(t, errors.As(errs[1], &depErr))
+	require.ErrorIs(t, errs[1], graph.ErrSelfLoop)
+	require.True(t, errors.As(errs[2], &duplicateDepErr))
+	require.Equal(t, 6, duplicateDepErr.Second)
+	require.ErrorIs(t, errs[3], graph.ErrVertexNotFound)
+	require.True(t, errors.As(errs[7], &cycleErr))
+
+	// Options suppress the respective problems
+	errs = Validate(allStrings, stringDependencies, KeepDuplicates(), IgnoreMissingElements())
+	require.Len(t, errs, 4)
+
+	// Valid data / dependencies yield no problems
+	require.Nil(t, Validate(allStrings[:8], stringDependencies[:5]))
+	require.Nil(t, Validate[string](nil, nil))
+}